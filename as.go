@@ -0,0 +1,61 @@
+package wrap
+
+import "errors"
+
+// AsType finds the first error in err's chain that is assignable to T, and if
+// one is found, returns it and true. Otherwise, it returns the zero value of T
+// and false. It is equivalent to calling errors.As with a pointer to a
+// variable of type T, so errors returned by With are searched front first and
+// then back.
+func AsType[T error](err error) (T, bool) {
+	var target T
+	if errors.As(err, &target) {
+		return target, true
+	}
+	var zero T
+	return zero, false
+}
+
+// First returns the first error in err's chain, as returned by Chain, that is
+// assignable to T, and true. If there is none, it returns the zero value of T
+// and false.
+//
+// Unlike AsType, First only looks at the errors themselves. It doesn't call
+// their As methods, so an error can't claim to be a T without being one.
+func First[T error](err error) (T, bool) {
+	var found T
+	var ok bool
+	walk(err, func(err error) bool {
+		found, ok = err.(T)
+		return !ok
+	})
+	return found, ok
+}
+
+// Last is like First, but returns the last error in err's chain that is
+// assignable to T, so it always walks the whole chain.
+func Last[T error](err error) (T, bool) {
+	var found T
+	var ok bool
+	walk(err, func(err error) bool {
+		if t, is := err.(T); is {
+			found, ok = t, true
+		}
+		return true
+	})
+	return found, ok
+}
+
+// Collect returns every error in err's chain that is assignable to T, in the
+// order errors.Is and errors.As visit them. Like First, it doesn't call As
+// methods. If there are none, it returns nil.
+func Collect[T error](err error) []T {
+	var found []T
+	walk(err, func(err error) bool {
+		if t, ok := err.(T); ok {
+			found = append(found, t)
+		}
+		return true
+	})
+	return found
+}
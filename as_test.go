@@ -0,0 +1,98 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestAsType(t *testing.T) {
+	err := wrap.With(fmt.Errorf("wilbur: %w", myError("some pig")), NotFound)
+	err = wrap.With(err, fmt.Errorf("some other error: %w", otherError{msg: "hi!"}))
+
+	my, ok := wrap.AsType[myError](err)
+	if !ok || my != "some pig" {
+		t.Fatalf("failed to find back type, got %q", my)
+	}
+	other, ok := wrap.AsType[otherError](err)
+	if !ok || other.msg != "hi!" {
+		t.Fatalf("failed to find front type, got %q", other.msg)
+	}
+
+	other, ok = wrap.AsType[otherError](errors.New("nope"))
+	if ok || other != (otherError{}) {
+		t.Fatalf("expected zero value and false but got %v, %v", other, ok)
+	}
+}
+
+// asMyError claims to be a myError via its As method.
+type asMyError struct{}
+
+func (asMyError) Error() string {
+	return "as my error"
+}
+
+func (asMyError) As(target interface{}) bool {
+	if my, ok := target.(*myError); ok {
+		*my = "claimed"
+		return true
+	}
+	return false
+}
+
+func TestFirst(t *testing.T) {
+	err := wrap.With(myError("some pig"), asMyError{})
+	err = wrap.With(err, fmt.Errorf("some other error: %w", otherError{msg: "hi!"}))
+
+	my, ok := wrap.First[myError](err)
+	if !ok || my != "some pig" {
+		t.Fatalf("expected to skip As method but got %q", my)
+	}
+	if my, _ := wrap.AsType[myError](err); my != "claimed" {
+		t.Fatalf("expected AsType to use As method but got %q", my)
+	}
+
+	other, ok := wrap.First[otherError](err)
+	if !ok || other.msg != "hi!" {
+		t.Fatalf("failed to find front type, got %q", other.msg)
+	}
+
+	// Interface types match any error that implements them.
+	wrapper, ok := wrap.First[interface {
+		error
+		Unwrap() error
+	}](err)
+	if !ok || wrapper.Error() != "some other error: hi!" {
+		t.Fatalf("expected first wrapping error but got %v", wrapper)
+	}
+}
+
+func TestLast(t *testing.T) {
+	err := wrap.With(fmt.Errorf("wilbur: %w", myError("inner")), NotFound)
+	err = wrap.With(err, myError("outer"))
+
+	if my, ok := wrap.Last[myError](err); !ok || my != "inner" {
+		t.Fatalf("expected innermost error but got %q", my)
+	}
+	if my, ok := wrap.First[myError](err); !ok || my != "outer" {
+		t.Fatalf("expected outermost error but got %q", my)
+	}
+	if other, ok := wrap.Last[otherError](err); ok || other != (otherError{}) {
+		t.Fatalf("expected zero value and false but got %v, %v", other, ok)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	err := wrap.With(myError("bottom"), fmt.Errorf("middle: %w", myError("middle")))
+	err = wrap.With(wrap.With(err, NotFound), myError("top"))
+
+	found := wrap.Collect[myError](err)
+	if actual, expected := fmt.Sprint(found), "[top middle bottom]"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if found := wrap.Collect[otherError](err); found != nil {
+		t.Fatalf("expected nil but got %v", found)
+	}
+}
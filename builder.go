@@ -0,0 +1,38 @@
+package wrap
+
+// Builder builds up a stack of errors one at a time, as an alternative to
+// nesting calls to With. The zero value is ready to use.
+type Builder struct {
+	err error
+}
+
+// Base sets the error at the bottom of the stack, replacing anything added so
+// far.
+func (b *Builder) Base(err error) *Builder {
+	b.err = err
+	return b
+}
+
+// Add wraps front over the errors added so far, as in With. If nothing has
+// been added yet, front becomes the base. Adding nil does nothing.
+func (b *Builder) Add(front error) *Builder {
+	if b.err == nil {
+		b.err = front
+	} else {
+		b.err = With(b.err, front)
+	}
+	return b
+}
+
+// Addf wraps an error built from format and args over the errors added so far,
+// as in Withf. If nothing has been added yet, the formatted error becomes the
+// base.
+func (b *Builder) Addf(format string, args ...interface{}) *Builder {
+	b.err = Withf(b.err, format, args...)
+	return b
+}
+
+// Err returns the stack of errors built so far, or nil if nothing was added.
+func (b *Builder) Err() error {
+	return b.err
+}
@@ -0,0 +1,45 @@
+package wrap_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestBuilder(t *testing.T) {
+	base := errors.New("some pig")
+	var b wrap.Builder
+	err := b.Base(base).Add(NotFound).Add(nil).Addf("line %d", 5).Add(io.EOF).Err()
+
+	expected := wrap.With(wrap.Withf(wrap.With(base, NotFound), "line %d", 5), io.EOF)
+	if err.Error() != expected.Error() {
+		t.Fatalf("expected %v but got %v", expected, err)
+	}
+	actual := wrap.Chain(err)
+	want := wrap.Chain(expected)
+	if len(actual) != len(want) {
+		t.Fatalf("expected %v but got %v", want, actual)
+	}
+	for i := range want {
+		if actual[i].Error() != want[i].Error() {
+			t.Fatalf("expected %v at index %d but got %v", want[i], i, actual[i])
+		}
+	}
+	for _, target := range []error{base, NotFound, io.EOF} {
+		if !errors.Is(err, target) {
+			t.Fatalf("failed to find %v", target)
+		}
+	}
+}
+
+func TestBuilderEmpty(t *testing.T) {
+	var b wrap.Builder
+	if err := b.Add(nil).Err(); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	if err := b.Add(NotFound).Err(); err != NotFound {
+		t.Fatalf("expected first error to become the base but got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package wrap
+
+import "errors"
+
+// WithCause returns an error that represents err caused by cause. It is the
+// same as With(cause, err), for code that reads better naming the error first,
+// so the returned error's message is err's message followed by
+// DefaultSeparator and cause's message, and Cause returns cause. If cause is
+// nil, err is returned unchanged, and if err is nil, cause is returned.
+func WithCause(err, cause error) error {
+	if cause == nil {
+		return err
+	}
+	return With(cause, err)
+}
+
+// Cause returns the cause of the first error in err's chain with a Cause()
+// error method, such as those returned by WithCause and With, for which it is
+// their back error. Errors from github.com/pkg/errors have the method too. If
+// no error in the chain has a cause, Cause returns nil.
+func Cause(err error) error {
+	var c interface{ Cause() error }
+	if errors.As(err, &c) {
+		return c.Cause()
+	}
+	return nil
+}
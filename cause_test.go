@@ -0,0 +1,38 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+var ErrSaveFailed = errors.New("save failed")
+
+func TestWithCause(t *testing.T) {
+	cause := fmt.Errorf("disk full: %w", NotFound)
+	err := wrap.WithCause(ErrSaveFailed, cause)
+	if actual, expected := err.Error(), "save failed: disk full: not found"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if c := wrap.Cause(err); c != cause {
+		t.Fatalf("expected cause %v but got %v", cause, c)
+	}
+	if c := wrap.Cause(fmt.Errorf("context: %w", err)); c != cause {
+		t.Fatalf("expected cause through wrapping but got %v", c)
+	}
+	if !errors.Is(err, ErrSaveFailed) || !errors.Is(err, NotFound) {
+		t.Fatal("failed to find wrapped errors")
+	}
+
+	if c := wrap.Cause(ErrSaveFailed); c != nil {
+		t.Fatalf("expected no cause but got %v", c)
+	}
+	if err := wrap.WithCause(ErrSaveFailed, nil); err != ErrSaveFailed {
+		t.Fatalf("expected error unchanged but got %v", err)
+	}
+	if err := wrap.WithCause(nil, cause); err != cause {
+		t.Fatalf("expected cause but got %v", err)
+	}
+}
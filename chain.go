@@ -0,0 +1,212 @@
+package wrap
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Root returns the innermost error in err's chain, found by calling
+// errors.Unwrap until it returns nil. For an error returned by With, this is
+// the innermost error in back. If err doesn't wrap another error, it is
+// returned unchanged.
+func Root(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// SameRoot reports whether a and b come from the same underlying error, which
+// is when errors.Is(Root(a), Root(b)) is true. If either is nil, SameRoot
+// reports whether both are.
+func SameRoot(a, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return errors.Is(Root(a), Root(b))
+}
+
+// Top returns the front error of err and true if err was returned by With or
+// one of the other functions in this package that wrap one error over another.
+// Otherwise, it returns nil and false. The front error is returned as is, not
+// unwrapped any further.
+func Top(err error) (error, bool) {
+	if s, ok := err.(*stack); ok {
+		return s.front, true
+	}
+	return nil, false
+}
+
+// Bottom returns the back error of err and true if err was returned by With
+// or one of the other functions in this package that wrap one error over
+// another. Otherwise, it returns nil and false. Unlike Root, which unwraps all
+// the way to the innermost error, Bottom only goes down one level, so if the
+// back error wraps other errors, they are still wrapped.
+func Bottom(err error) (error, bool) {
+	if s, ok := err.(*stack); ok {
+		return s.back, true
+	}
+	return nil, false
+}
+
+// Peel removes the outermost layer of err. For an error returned by With, that
+// is the whole front error, so Peel(With(back, front)) returns back. This
+// differs from errors.Unwrap, which returns a stack of front's own wrapped
+// errors over back if front wraps anything, so that repeated calls visit
+// every error. For any other error, Peel returns errors.Unwrap(err).
+func Peel(err error) error {
+	if s, ok := err.(*stack); ok {
+		return s.back
+	}
+	return errors.Unwrap(err)
+}
+
+// Chain returns every error in err's chain, starting with the outermost, in the
+// order that errors.Is and errors.As visit them. Errors returned by With are
+// not included themselves; in their place are the errors from front's chain
+// followed by the errors from back's chain. Other errors that wrap errors,
+// like those from fmt.Errorf, are included along with the errors they wrap.
+//
+// If err is nil, Chain returns an empty slice.
+func Chain(err error) []error {
+	errs := []error{}
+	walk(err, func(err error) bool {
+		errs = append(errs, err)
+		return true
+	})
+	return errs
+}
+
+// Depth returns the number of errors in err's chain, which is the length of the
+// slice Chain would return. Errors returned by With are not counted
+// themselves, but every error in the chains of their front and back errors
+// is. So an error that doesn't wrap anything has a depth of 1, With(a, b) has
+// a depth of 2 if neither a nor b wrap anything, and nil has a depth of 0.
+func Depth(err error) int {
+	var n int
+	walk(err, func(error) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// TypeNames returns the type of each error in err's chain, as formatted by
+// reflect.Type's String method, in the same order as Chain. It is meant for
+// labelling errors in metrics, where the types in a chain say more than its
+// messages and have far fewer possible values. If err is nil, TypeNames
+// returns an empty slice.
+func TypeNames(err error) []string {
+	names := []string{}
+	walk(err, func(err error) bool {
+		names = append(names, reflect.TypeOf(err).String())
+		return true
+	})
+	return names
+}
+
+// Walk calls fn for each error in err's chain, in the same order as Chain. If fn
+// returns false, Walk stops without visiting the rest of the chain. If err is
+// nil, fn is never called.
+func Walk(err error, fn func(error) bool) {
+	walk(err, fn)
+}
+
+// Find returns the first error in err's chain, in the same order as Chain, for
+// which pred returns true, and true. If there is none, it returns nil and
+// false.
+func Find(err error, pred func(error) bool) (error, bool) {
+	var found error
+	walk(err, func(err error) bool {
+		if pred(err) {
+			found = err
+			return false
+		}
+		return true
+	})
+	return found, found != nil
+}
+
+// IsFunc reports whether eq returns true for any error in err's chain, in the
+// same order as Chain. It is like errors.Is, for errors that can't be matched
+// by comparing them with a target, and is the same as checking the bool
+// returned by Find.
+func IsFunc(err error, eq func(error) bool) bool {
+	_, found := Find(err, eq)
+	return found
+}
+
+// walk calls fn for each error in err's chain, in the order described in
+// Chain, until fn returns false. It reports whether it reached the end of the
+// chain.
+func walk(err error, fn func(error) bool) bool {
+	for err != nil {
+		if s, ok := err.(*stack); ok {
+			if !walk(s.front, fn) {
+				return false
+			}
+			err = s.back
+			continue
+		}
+		if !fn(err) {
+			return false
+		}
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, err := range x.Unwrap() {
+				if !walk(err, fn) {
+					return false
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	}
+	return true
+}
+
+// Flatten returns the errors that make up err, in the order that errors.Is and
+// errors.As visit them. Unlike Chain, it only looks inside errors returned by
+// With and the like, replacing each with the errors from flattening its front
+// and then its back. Any other error is included as is, even if it wraps other
+// errors, so a chain from fmt.Errorf isn't broken up. If err is nil, Flatten
+// returns an empty slice.
+func Flatten(err error) []error {
+	if err == nil {
+		return []error{}
+	}
+	return flatten(err, nil)
+}
+
+// Messages returns the message of each error that Flatten would return, in the
+// same order, rather than the joined message returned by Error. Errors with
+// empty messages, like those added by WithTag, are left out, so every entry is
+// something a person can read. If err is nil, Messages returns an empty slice.
+func Messages(err error) []string {
+	msgs := []string{}
+	if err == nil {
+		return msgs
+	}
+	for _, err := range flatten(err, nil) {
+		if msg := err.Error(); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// flatten appends the errors that make up err to errs and returns the result.
+// Errors returned by With are replaced by the errors from flattening front and
+// then back, and any other error is appended as is.
+func flatten(err error, errs []error) []error {
+	if s, ok := err.(*stack); ok {
+		return flatten(s.back, flatten(s.front, errs))
+	}
+	return append(errs, err)
+}
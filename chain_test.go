@@ -0,0 +1,262 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestRoot(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", base)
+	err := wrap.With(wrapped, fmt.Errorf("flagged: %w", NotFound))
+	err = fmt.Errorf("more context: %w", wrap.With(err, io.EOF))
+
+	if root := wrap.Root(err); root != base {
+		t.Fatalf("expected %v but got %v", base, root)
+	}
+	if root := wrap.Root(base); root != base {
+		t.Fatalf("expected %v but got %v", base, root)
+	}
+	if root := wrap.Root(nil); root != nil {
+		t.Fatalf("expected nil but got %v", root)
+	}
+}
+
+func TestChain(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", base)
+	flagged := fmt.Errorf("flagged: %w", NotFound)
+	joined := errors.Join(io.EOF, io.ErrClosedPipe)
+	err := wrap.With(wrap.With(wrapped, flagged), joined)
+
+	expected := []error{joined, io.EOF, io.ErrClosedPipe, flagged, NotFound, wrapped, base}
+	actual := wrap.Chain(err)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected %v at index %d but got %v", expected[i], i, actual[i])
+		}
+	}
+
+	if errs := wrap.Chain(nil); errs == nil || len(errs) != 0 {
+		t.Fatalf("expected empty slice but got %#v", errs)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", base)
+	err := wrap.With(wrap.With(wrapped, NotFound), io.EOF)
+
+	var actual []error
+	wrap.Walk(err, func(e error) bool {
+		actual = append(actual, e)
+		return e != NotFound
+	})
+	expected := []error{io.EOF, NotFound}
+	if len(actual) != len(expected) || actual[0] != expected[0] || actual[1] != expected[1] {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	wrap.Walk(nil, func(e error) bool {
+		t.Fatalf("expected no calls but got %v", e)
+		return true
+	})
+}
+
+func TestTop(t *testing.T) {
+	base := errors.New("some pig")
+	flagged := fmt.Errorf("flagged: %w", NotFound)
+	err := wrap.With(base, flagged)
+
+	if top, ok := wrap.Top(err); !ok || top != flagged {
+		t.Fatalf("expected %v but got %v", flagged, top)
+	}
+	if top, ok := wrap.Top(wrap.With(base, NotFound)); !ok || top != NotFound {
+		t.Fatalf("expected %v but got %v", NotFound, top)
+	}
+	if top, ok := wrap.Top(base); ok || top != nil {
+		t.Fatalf("expected nil and false but got %v, %v", top, ok)
+	}
+}
+
+func TestBottom(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", base)
+	err := wrap.With(wrap.With(wrapped, NotFound), io.EOF)
+
+	back, ok := wrap.Bottom(err)
+	if !ok {
+		t.Fatal("expected a stack")
+	}
+	if back, ok = wrap.Bottom(back); !ok || back != wrapped {
+		t.Fatalf("expected %v but got %v", wrapped, back)
+	}
+	if back, ok := wrap.Bottom(wrapped); ok || back != nil {
+		t.Fatalf("expected nil and false but got %v, %v", back, ok)
+	}
+}
+
+func TestDepth(t *testing.T) {
+	base := errors.New("some pig")
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{nil, 0},
+		{base, 1},
+		{fmt.Errorf("wilbur: %w", base), 2},
+		{wrap.With(base, NotFound), 2},
+		{wrap.With(fmt.Errorf("wilbur: %w", base), NotFound), 3},
+		{wrap.WithAll(base, NotFound, io.EOF, io.ErrClosedPipe), 4},
+	}
+	for _, test := range tests {
+		if actual := wrap.Depth(test.err); actual != test.expected {
+			t.Errorf("%v: expected %d but got %d", test.err, test.expected, actual)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", base)
+	flagged := fmt.Errorf("flagged: %w", NotFound)
+	err := wrap.With(wrap.With(wrap.With(wrapped, flagged), io.EOF), wrap.With(io.ErrClosedPipe, ErrTxnFailed))
+
+	expected := []error{ErrTxnFailed, io.ErrClosedPipe, io.EOF, flagged, wrapped}
+	actual := wrap.Flatten(err)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected %v at index %d but got %v", expected[i], i, actual[i])
+		}
+	}
+
+	if errs := wrap.Flatten(base); len(errs) != 1 || errs[0] != base {
+		t.Fatalf("expected just the error but got %v", errs)
+	}
+	if errs := wrap.Flatten(nil); errs == nil || len(errs) != 0 {
+		t.Fatalf("expected empty slice but got %#v", errs)
+	}
+}
+
+func TestPeel(t *testing.T) {
+	base := errors.New("some pig")
+	flagged := fmt.Errorf("flagged: %w", NotFound)
+	inner := wrap.With(base, io.EOF)
+	err := wrap.With(inner, flagged)
+
+	if peeled := wrap.Peel(err); peeled != inner {
+		t.Fatalf("expected %v but got %v", inner, peeled)
+	}
+	if unwrapped := errors.Unwrap(err); unwrapped.Error() != "not found: EOF: some pig" {
+		t.Fatalf("expected Unwrap to descend into front but got %v", unwrapped)
+	}
+	if peeled := wrap.Peel(wrap.Peel(err)); peeled != base {
+		t.Fatalf("expected %v but got %v", base, peeled)
+	}
+	if peeled := wrap.Peel(flagged); peeled != NotFound {
+		t.Fatalf("expected %v but got %v", NotFound, peeled)
+	}
+	if peeled := wrap.Peel(base); peeled != nil {
+		t.Fatalf("expected nil but got %v", peeled)
+	}
+}
+
+func TestTypeNames(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "/pig", Err: fs.ErrNotExist}
+	err := wrap.With(fmt.Errorf("read config: %w", pathErr), myError("some pig"))
+	err = wrap.With(err, NotFound)
+
+	actual := wrap.TypeNames(err)
+	expected := []string{"*errors.errorString", "wrap_test.myError", "*fmt.wrapError", "*fs.PathError", "*errors.errorString"}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if names := wrap.TypeNames(nil); names == nil || len(names) != 0 {
+		t.Fatalf("expected empty slice but got %#v", names)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	err := wrap.With(fmt.Errorf("wilbur: %w", errors.New("some pig")), NotFound)
+	err = wrap.With(wrap.WithTag(err, "barn"), errors.New("request failed"))
+
+	actual := wrap.Messages(err)
+	expected := []string{"request failed", "not found", "wilbur: some pig"}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if msgs := wrap.Messages(nil); msgs == nil || len(msgs) != 0 {
+		t.Fatalf("expected empty slice but got %#v", msgs)
+	}
+}
+
+func TestFind(t *testing.T) {
+	err := wrap.WithCode(fmt.Errorf("wilbur: %w", wrap.WithCode(errors.New("some pig"), 404)), 503)
+	retryable := map[int]bool{404: true}
+	found, ok := wrap.Find(err, func(err error) bool {
+		c, ok := err.(interface{ Code() int })
+		return ok && retryable[c.Code()]
+	})
+	if !ok || found.Error() != "code 404" {
+		t.Fatalf("expected inner code error but got %v", found)
+	}
+	if found, ok := wrap.Find(err, func(error) bool { return false }); ok || found != nil {
+		t.Fatalf("expected nothing found but got %v", found)
+	}
+	if found, ok := wrap.Find(nil, func(error) bool { return true }); ok || found != nil {
+		t.Fatalf("expected nothing found but got %v", found)
+	}
+}
+
+func TestSameRoot(t *testing.T) {
+	base := errors.New("some pig")
+	a := wrap.With(fmt.Errorf("wilbur: %w", base), NotFound)
+	b := fmt.Errorf("charlotte: %w", wrap.WithTag(wrap.With(base, io.EOF), "barn"))
+
+	tests := []struct {
+		a, b     error
+		expected bool
+	}{
+		{a, b, true},
+		{a, base, true},
+		{a, wrap.With(errors.New("some pig"), NotFound), false},
+		{a, nil, false},
+		{nil, b, false},
+		{nil, nil, true},
+	}
+	for _, test := range tests {
+		if actual := wrap.SameRoot(test.a, test.b); actual != test.expected {
+			t.Errorf("SameRoot(%v, %v): expected %v but got %v", test.a, test.b, test.expected, actual)
+		}
+	}
+}
+
+func TestIsFunc(t *testing.T) {
+	err := wrap.With(fmt.Errorf("wilbur: %w", otherError{msg: "hi!"}), NotFound)
+	hasMsg := func(msg string) func(error) bool {
+		return func(err error) bool {
+			o, ok := err.(otherError)
+			return ok && o.msg == msg
+		}
+	}
+	if !wrap.IsFunc(err, hasMsg("hi!")) {
+		t.Fatal("expected to match by field value")
+	}
+	if wrap.IsFunc(err, hasMsg("bye")) {
+		t.Fatal("unexpectedly matched")
+	}
+	if wrap.IsFunc(nil, func(error) bool { return true }) {
+		t.Fatal("expected nil error not to match")
+	}
+}
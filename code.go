@@ -0,0 +1,41 @@
+package wrap
+
+import (
+	"errors"
+	"strconv"
+)
+
+// CodedError is an error that carries an integer code. WithCode wraps one over
+// another error, and it can be retrieved from the chain with errors.As.
+type CodedError struct {
+	code int
+}
+
+// Error returns a message naming the code, like "code 404".
+func (e *CodedError) Error() string {
+	return "code " + strconv.Itoa(e.code)
+}
+
+// Code returns the error's code.
+func (e *CodedError) Code() int {
+	return e.code
+}
+
+// WithCode returns an error that represents a *CodedError with the given code
+// wrapped over back, so its message is like "code 404: " followed by back's
+// message. If back is nil, the returned error is nil.
+func WithCode(back error, code int) error {
+	return With(back, &CodedError{code: code})
+}
+
+// Code returns the code of the first error in err's chain that has a Code()
+// int method, such as a *CodedError, and true. Since the chain is searched
+// from the outside in, the code from the last call to WithCode wins. If no
+// error in the chain has a code, Code returns 0 and false.
+func Code(err error) (int, bool) {
+	var c interface{ Code() int }
+	if errors.As(err, &c) {
+		return c.Code(), true
+	}
+	return 0, false
+}
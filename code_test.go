@@ -0,0 +1,35 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithCode(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithCode(base, 404)
+	if actual, expected := err.Error(), "code 404: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	var coded *wrap.CodedError
+	if !errors.As(err, &coded) || coded.Code() != 404 {
+		t.Fatal("failed to find coded error")
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+
+	err = wrap.WithCode(fmt.Errorf("context: %w", err), 500)
+	if code, ok := wrap.Code(err); !ok || code != 500 {
+		t.Fatalf("expected outermost code 500 but got %v", code)
+	}
+	if code, ok := wrap.Code(base); ok || code != 0 {
+		t.Fatalf("expected no code but got %v", code)
+	}
+	if err := wrap.WithCode(nil, 404); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
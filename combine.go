@@ -0,0 +1,42 @@
+package wrap
+
+// combined is the error returned by Combine.
+type combined struct {
+	errs [2]error
+}
+
+// Error returns the two error messages separated by a semicolon.
+func (c *combined) Error() string {
+	return joinMessages(c.errs[0].Error(), c.errs[1].Error(), "; ")
+}
+
+// Unwrap returns both errors, so that errors.Is and errors.As search a and
+// then b.
+func (c *combined) Unwrap() []error {
+	return c.errs[:]
+}
+
+// Combine returns an error that represents two independent errors, rather than
+// one wrapped over the other. Its message is a's message followed by b's,
+// separated by "; ", and both a and b are visible to errors.Is and errors.As,
+// which search a first. If either error is nil, the other is returned.
+func Combine(a, b error) error {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return &combined{errs: [2]error{a, b}}
+}
+
+// FirstNonNil returns the first of errs that isn't nil, or nil if they all
+// are. Unlike Combine, the other errors are dropped.
+func FirstNonNil(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
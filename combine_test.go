@@ -0,0 +1,55 @@
+package wrap_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestCombine(t *testing.T) {
+	a := wrap.With(errors.New("write failed"), io.ErrShortWrite)
+	b := wrap.With(myError("close failed"), NotFound)
+	err := wrap.Combine(a, b)
+
+	if actual, expected := err.Error(), "short write: write failed; not found: close failed"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, io.ErrShortWrite) || !errors.Is(err, NotFound) {
+		t.Fatal("failed to find sentinels from both sides")
+	}
+	var my myError
+	if !errors.As(err, &my) || my != "close failed" {
+		t.Fatal("failed to find type from second error")
+	}
+
+	if err := wrap.Combine(nil, b); err != b {
+		t.Fatalf("expected second error but got %v", err)
+	}
+	if err := wrap.Combine(a, nil); err != a {
+		t.Fatalf("expected first error but got %v", err)
+	}
+	if err := wrap.Combine(nil, nil); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestFirstNonNil(t *testing.T) {
+	a := errors.New("some pig")
+	b := errors.New("terrific")
+	tests := []struct {
+		errs     []error
+		expected error
+	}{
+		{nil, nil},
+		{[]error{nil, nil}, nil},
+		{[]error{nil, nil, b}, b},
+		{[]error{a, b}, a},
+	}
+	for _, test := range tests {
+		if actual := wrap.FirstNonNil(test.errs...); actual != test.expected {
+			t.Errorf("%v: expected %v but got %v", test.errs, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package wrap
+
+import (
+	"context"
+	"errors"
+)
+
+// IsCanceled reports whether err's chain contains context.Canceled.
+func IsCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// IsDeadlineExceeded reports whether err's chain contains
+// context.DeadlineExceeded.
+func IsDeadlineExceeded(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// TraceKey is the context key WithTrace uses to look up trace IDs. Set it,
+// during program initialization, to the key your tracing code stores trace ID
+// strings under with context.WithValue.
+var TraceKey interface{}
+
+// traceError is the error created by WithTrace. Its message is empty, so it
+// doesn't change the message of the error it is wrapped over.
+type traceError struct {
+	id string
+}
+
+func (e *traceError) Error() string {
+	return ""
+}
+
+func (e *traceError) TraceID() string {
+	return e.id
+}
+
+// WithTrace returns an error that represents an error carrying the trace ID
+// stored in ctx under TraceKey wrapped over back, which can be retrieved with
+// TraceID. The returned error's message is the same as back's. If ctx has no
+// string value for TraceKey, back is returned unchanged, and if back is nil,
+// the returned error is nil.
+func WithTrace(ctx context.Context, back error) error {
+	if TraceKey == nil {
+		return back
+	}
+	id, ok := ctx.Value(TraceKey).(string)
+	if !ok {
+		return back
+	}
+	return With(back, &traceError{id: id})
+}
+
+// TraceID returns the trace ID of the first error in err's chain with a
+// TraceID() string method, such as one added by WithTrace, and true. If no
+// error in the chain has a trace ID, it returns "" and false.
+func TraceID(err error) (string, bool) {
+	var t interface{ TraceID() string }
+	if errors.As(err, &t) {
+		return t.TraceID(), true
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package wrap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestIsCanceled(t *testing.T) {
+	err := wrap.With(wrap.With(context.Canceled, NotFound), ErrTxnFailed)
+	if !wrap.IsCanceled(err) {
+		t.Fatal("failed to find context.Canceled")
+	}
+	if wrap.IsDeadlineExceeded(err) {
+		t.Fatal("unexpectedly found context.DeadlineExceeded")
+	}
+}
+
+func TestIsDeadlineExceeded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+
+	err := wrap.With(wrap.With(ctx.Err(), NotFound), ErrTxnFailed)
+	if !wrap.IsDeadlineExceeded(err) {
+		t.Fatal("failed to find context.DeadlineExceeded")
+	}
+	if wrap.IsCanceled(err) {
+		t.Fatal("unexpectedly found context.Canceled")
+	}
+}
+
+type traceKey struct{}
+
+func TestWithTrace(t *testing.T) {
+	defer func(key interface{}) { wrap.TraceKey = key }(wrap.TraceKey)
+	wrap.TraceKey = traceKey{}
+
+	base := errors.New("some pig")
+	ctx := context.WithValue(context.Background(), traceKey{}, "abc123")
+	err := wrap.With(wrap.WithTrace(ctx, base), NotFound)
+
+	if actual, expected := err.Error(), "not found: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if id, ok := wrap.TraceID(err); !ok || id != "abc123" {
+		t.Fatalf("expected trace ID abc123 but got %q", id)
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+
+	if err := wrap.WithTrace(context.Background(), base); err != base {
+		t.Fatalf("expected original error without a trace ID but got %v", err)
+	}
+	if id, ok := wrap.TraceID(base); ok || id != "" {
+		t.Fatalf("expected no trace ID but got %q", id)
+	}
+}
@@ -0,0 +1,54 @@
+package wrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dump returns a description of err meant for people reading crash logs. Each
+// error in err's tree gets its own line with its type and quoted message, and
+// the errors it wraps are listed under it, indented by two more spaces. For
+// errors returned by With, those are front and then back. If an error
+// recorded a stack trace with WithStack, the trace is listed below it, one
+// "at function (file:line)" line per frame, and if it was named by WithGroup,
+// its line ends with the group, like `group "db"`. If err is nil, Dump returns
+// "".
+func Dump(err error) string {
+	var b strings.Builder
+	dump(&b, err, "")
+	return b.String()
+}
+
+// dump writes the dump of err to b, with each line starting with indent.
+func dump(b *strings.Builder, err error, indent string) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(b, "%s%T %q", indent, err, err.Error())
+	if s, ok := err.(*stack); ok && s.group != "" {
+		fmt.Fprintf(b, " group %q", s.group)
+	}
+	b.WriteByte('\n')
+	indent += "  "
+	switch x := err.(type) {
+	case *stack:
+		if len(x.trace) > 0 {
+			frames := x.Frames()
+			for {
+				frame, more := frames.Next()
+				fmt.Fprintf(b, "%sat %s (%s:%d)\n", indent, frame.Function, frame.File, frame.Line)
+				if !more {
+					break
+				}
+			}
+		}
+		dump(b, x.front, indent)
+		dump(b, x.back, indent)
+	case interface{ Unwrap() error }:
+		dump(b, x.Unwrap(), indent)
+	case interface{ Unwrap() []error }:
+		for _, err := range x.Unwrap() {
+			dump(b, err, indent)
+		}
+	}
+}
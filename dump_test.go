@@ -0,0 +1,37 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestDump(t *testing.T) {
+	err := wrap.With(fmt.Errorf("wilbur: %w", errors.New("some pig")), NotFound)
+	actual := wrap.Dump(err)
+	expected := `*wrap.stack "not found: wilbur: some pig"
+  *errors.errorString "not found"
+  *fmt.wrapError "wilbur: some pig"
+    *errors.errorString "some pig"
+`
+	if actual != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, actual)
+	}
+	if dump := wrap.Dump(nil); dump != "" {
+		t.Fatalf("expected empty dump but got %q", dump)
+	}
+}
+
+func TestDumpStack(t *testing.T) {
+	err := wrap.WithStack(errors.New("some pig"), NotFound)
+	lines := strings.Split(wrap.Dump(err), "\n")
+	if !strings.HasPrefix(lines[1], "  at ") || !strings.Contains(lines[1], ".TestDumpStack (") {
+		t.Fatalf("expected stack trace starting in TestDumpStack but got %q", lines[1])
+	}
+	if last := lines[len(lines)-2]; last != `  *errors.errorString "some pig"` {
+		t.Fatalf("expected back error last but got %q", last)
+	}
+}
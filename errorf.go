@@ -0,0 +1,39 @@
+package wrap
+
+import "fmt"
+
+// formatted is the error returned by Errorf when it wraps more than one error.
+type formatted struct {
+	msg string
+	err error
+}
+
+func (f *formatted) Error() string {
+	return f.msg
+}
+
+func (f *formatted) Unwrap() error {
+	return f.err
+}
+
+// Errorf formats an error message like fmt.Errorf, including support for %w.
+// With zero or one %w verbs, it returns the same error as fmt.Errorf. With more
+// than one, fmt.Errorf returns an error whose wrapped errors are siblings in a
+// tree that errors.Is and errors.As search in argument order. Errorf instead
+// stacks them as With does, with the first %w argument at the bottom and each
+// later one wrapped over it, so the last %w argument is searched first. The
+// returned error's message is the formatted message, regardless of how the
+// errors are stacked.
+func Errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	multi, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return err
+	}
+	errs := multi.Unwrap()
+	if len(errs) == 0 {
+		// Every %w argument was nil, so there is nothing to stack.
+		return err
+	}
+	return &formatted{msg: err.Error(), err: WithAll(errs[0], errs[1:]...)}
+}
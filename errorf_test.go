@@ -0,0 +1,47 @@
+package wrap_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestErrorf(t *testing.T) {
+	base := myError("some pig")
+	err := wrap.Errorf("reading %q: %w (%w)", "file", base, NotFound)
+
+	if actual, expected := err.Error(), `reading "file": some pig (not found)`; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, base) || !errors.Is(err, NotFound) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	chain := wrap.Chain(errors.Unwrap(err))
+	if len(chain) != 2 || chain[0] != NotFound || chain[1] != base {
+		t.Fatalf("expected last %%w to be outermost but got %v", chain)
+	}
+	if root := wrap.Root(err); root != base {
+		t.Fatalf("expected first %%w at the bottom but got %v", root)
+	}
+
+	err = wrap.Errorf("reading: %w", io.EOF)
+	if errors.Unwrap(err) != io.EOF {
+		t.Fatal("expected a single %w to wrap like fmt.Errorf")
+	}
+	err = wrap.Errorf("reading %d", 5)
+	if err.Error() != "reading 5" || errors.Unwrap(err) != nil {
+		t.Fatalf("expected plain formatted error but got %v", err)
+	}
+}
+
+func TestErrorfNilWrapped(t *testing.T) {
+	err := wrap.Errorf("%w and %w", nil, nil)
+	if actual, expected := err.Error(), "%!w(<nil>) and %!w(<nil>)"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if errors.Is(err, io.EOF) {
+		t.Fatal("unexpectedly matched")
+	}
+}
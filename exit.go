@@ -0,0 +1,46 @@
+package wrap
+
+import "errors"
+
+// exitError is the error created by WithExitCode. Its message is empty, so it
+// doesn't change the message of the error it is wrapped over.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return ""
+}
+
+func (e *exitError) ExitCode() int {
+	return e.code
+}
+
+// WithExitCode returns an error that represents an error carrying the exit
+// code a program should use if err ends it wrapped over back, which can be
+// retrieved with ExitCode. The returned error's message is the same as back's.
+// If back is nil, the returned error is nil.
+func WithExitCode(back error, code int) error {
+	return With(back, &exitError{code: code})
+}
+
+// ExitCode returns the exit code a program should use when err ends it, so main
+// can end with:
+//
+//	os.Exit(wrap.ExitCode(err))
+//
+// The code is that of the first error in err's chain with an ExitCode() int
+// method, such as those added by WithExitCode, so the code from the last call
+// to WithExitCode wins. Errors from os/exec for commands that failed have the
+// method too, so their exit codes are passed on. If no error in the chain has
+// an exit code, ExitCode returns 1, and if err is nil, it returns 0.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var e interface{ ExitCode() int }
+	if errors.As(err, &e) {
+		return e.ExitCode()
+	}
+	return 1
+}
@@ -0,0 +1,36 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithExitCode(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithExitCode(base, 2)
+	if actual, expected := err.Error(), "some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if code := wrap.ExitCode(err); code != 2 {
+		t.Fatalf("expected 2 but got %v", code)
+	}
+	err = wrap.WithExitCode(fmt.Errorf("context: %w", wrap.With(err, NotFound)), 3)
+	if code := wrap.ExitCode(err); code != 3 {
+		t.Fatalf("expected outermost exit code 3 but got %v", code)
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+	if code := wrap.ExitCode(base); code != 1 {
+		t.Fatalf("expected default of 1 but got %v", code)
+	}
+	if code := wrap.ExitCode(nil); code != 0 {
+		t.Fatalf("expected 0 but got %v", code)
+	}
+	if err := wrap.WithExitCode(nil, 2); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
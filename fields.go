@@ -0,0 +1,83 @@
+package wrap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// fieldsError is the error created by Annotate and WithFields.
+type fieldsError struct {
+	fields map[string]interface{}
+}
+
+// Error returns the fields as key=value pairs, sorted by key and separated by
+// spaces.
+func (e *fieldsError) Error() string {
+	keys := make([]string, 0, len(e.fields))
+	for k := range e.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s=%v", k, e.fields[k])
+	}
+	return b.String()
+}
+
+func (e *fieldsError) Fields() map[string]interface{} {
+	return e.fields
+}
+
+// Annotate returns an error that represents an error carrying the given key
+// and value wrapped over back, so its message is like "key=value: " followed
+// by back's message. The annotation can be retrieved with Fields. If back is
+// nil, the returned error is nil.
+func Annotate(back error, key string, value interface{}) error {
+	return With(back, &fieldsError{fields: map[string]interface{}{key: value}})
+}
+
+// WithFields is like Annotate, but attaches all of fields at once, so the
+// returned error's message is like "a=1 b=2: " followed by back's message,
+// with the fields sorted by key. The map is copied, so changing it afterward
+// doesn't change the error. If fields is empty, back is returned unchanged,
+// and if back is nil, the returned error is nil.
+func WithFields(back error, fields map[string]interface{}) error {
+	if len(fields) == 0 {
+		return back
+	}
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return With(back, &fieldsError{fields: copied})
+}
+
+// Fields returns the fields of every error in err's chain that has a Fields()
+// map[string]interface{} method, such as those added by Annotate and
+// WithFields, merged into one map. If more than one error has a field with the
+// same key, the value from the outermost error wins. If no error in the chain
+// has fields, Fields returns nil.
+func Fields(err error) map[string]interface{} {
+	var fields map[string]interface{}
+	walk(err, func(err error) bool {
+		f, ok := err.(interface{ Fields() map[string]interface{} })
+		if !ok {
+			return true
+		}
+		for k, v := range f.Fields() {
+			if fields == nil {
+				fields = map[string]interface{}{}
+			}
+			if _, ok := fields[k]; !ok {
+				fields[k] = v
+			}
+		}
+		return true
+	})
+	return fields
+}
@@ -0,0 +1,60 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestAnnotate(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.Annotate(base, "user", 5)
+	if actual, expected := err.Error(), "user=5: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+
+	err = wrap.Annotate(fmt.Errorf("context: %w", err), "request", "abc")
+	err = wrap.Annotate(wrap.With(err, NotFound), "user", 7)
+
+	fields := wrap.Fields(err)
+	expected := map[string]interface{}{"user": 7, "request": "abc"}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %v but got %v", expected, fields)
+	}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Fatalf("expected %v for %q but got %v", v, k, fields[k])
+		}
+	}
+
+	if fields := wrap.Fields(base); fields != nil {
+		t.Fatalf("expected no fields but got %v", fields)
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	base := errors.New("some pig")
+	fields := map[string]interface{}{"user": 5, "request": "abc", "attempt": 1}
+	err := wrap.WithFields(base, fields)
+	if actual, expected := err.Error(), "attempt=1 request=abc user=5: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	fields["user"] = 6
+	err = wrap.WithFields(wrap.With(err, NotFound), map[string]interface{}{"attempt": 2, "host": "barn"})
+
+	actual := fmt.Sprint(wrap.Fields(err))
+	if expected := "map[attempt:2 host:barn request:abc user:5]"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if err := wrap.WithFields(base, nil); err != base {
+		t.Fatalf("expected back error unchanged but got %v", err)
+	}
+	if err := wrap.WithFields(nil, fields); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package wrap
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format implements fmt.Formatter. The %s and %v verbs print the same message
+// as Error, %q prints that message quoted, and any other verb formats it as it
+// would a string, so %x prints it in hex. The %+v verb prints front and then
+// back on separate lines, each formatted with %+v, so nested stacks get a line
+// per error and errors that implement fmt.Formatter print their verbose forms.
+// A stack named by WithGroup starts with a line like `group "db"`.
+func (s *stack) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			if s.group != "" {
+				fmt.Fprintf(f, "group %q\n", s.group)
+			}
+			fmt.Fprintf(f, "%+v\n%+v", s.front, s.back)
+			return
+		}
+		io.WriteString(f, s.Error())
+	case 's':
+		io.WriteString(f, s.Error())
+	case 'q':
+		fmt.Fprintf(f, "%q", s.Error())
+	default:
+		// Other verbs, like %x, format the message as they would any string,
+		// as they do for other errors.
+		fmt.Fprintf(f, fmt.FormatString(f, verb), s.Error())
+	}
+}
+
+// String returns a debugging form of s showing its front and back messages
+// separately, like `stack{front: "not found", back: "some pig"}`. The fmt
+// package never calls it, since Format takes precedence and prints the same
+// message as Error, so it is only used by code that asks for a fmt.Stringer
+// explicitly.
+func (s *stack) String() string {
+	return fmt.Sprintf("stack{front: %q, back: %q}", s.front.Error(), s.back.Error())
+}
@@ -0,0 +1,65 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+type verboseError struct{}
+
+func (verboseError) Error() string {
+	return "verbose"
+}
+
+func (verboseError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprint(f, "verbose with details")
+		return
+	}
+	fmt.Fprint(f, "verbose")
+}
+
+func TestFormat(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.With(wrap.With(base, verboseError{}), fmt.Errorf("wilbur: %w", NotFound))
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "wilbur: not found: verbose: some pig"},
+		{"%s", "wilbur: not found: verbose: some pig"},
+		{"%q", `"wilbur: not found: verbose: some pig"`},
+		{"%+v", "wilbur: not found\nverbose with details\nsome pig"},
+		{"%x", fmt.Sprintf("%x", "wilbur: not found: verbose: some pig")},
+		{"% X", fmt.Sprintf("% X", "wilbur: not found: verbose: some pig")},
+		{"%d", "%!d(string=wilbur: not found: verbose: some pig)"},
+	}
+	for _, test := range tests {
+		actual := fmt.Sprintf(test.format, err)
+		if actual != test.expected {
+			t.Errorf("%s: expected %q but got %q", test.format, test.expected, actual)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	err := wrap.With(wrap.With(errors.New("some pig"), NotFound), io.EOF)
+	str, ok := err.(fmt.Stringer)
+	if !ok {
+		t.Fatal("expected error to implement fmt.Stringer")
+	}
+	if actual, expected := str.String(), `stack{front: "EOF", back: "not found: some pig"}`; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if actual, expected := fmt.Sprint(str), "EOF: not found: some pig"; actual != expected {
+		t.Fatalf("expected fmt to use Error but got %v", actual)
+	}
+	if actual, expected := fmt.Sprint(err), "EOF: not found: some pig"; actual != expected {
+		t.Fatalf("expected fmt to use Error but got %v", actual)
+	}
+}
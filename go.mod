@@ -1,3 +1,3 @@
 module github.com/natefinch/wrap
 
-go 1.13
+go 1.20
@@ -0,0 +1,43 @@
+package wrap
+
+// WithGroup returns an error that represents each of fronts wrapped in turn
+// over back, as in WithAll, with name recorded on the outermost wrapping. The
+// name labels the errors that came from one subsystem, and can be retrieved
+// with Groups. It is shown by Dump and the %+v verb, but it isn't part of the
+// message returned by Error. If no errors were wrapped over back, because
+// fronts is empty or only holds nil errors, back is returned unchanged and the
+// name isn't recorded.
+func WithGroup(back error, name string, fronts ...error) error {
+	err := WithAll(back, fronts...)
+	if s, ok := err.(*stack); ok && err != back {
+		s.group = name
+	}
+	return err
+}
+
+// Groups returns the names given by WithGroup to the errors in err's tree,
+// outermost first. If no errors were named, Groups returns nil.
+func Groups(err error) []string {
+	return groups(err, nil)
+}
+
+// groups appends the group names in err's tree to names, in the same order as
+// Dump lists the errors they belong to.
+func groups(err error, names []string) []string {
+	switch x := err.(type) {
+	case nil:
+	case *stack:
+		if x.group != "" {
+			names = append(names, x.group)
+		}
+		names = groups(x.front, names)
+		names = groups(x.back, names)
+	case interface{ Unwrap() error }:
+		names = groups(x.Unwrap(), names)
+	case interface{ Unwrap() []error }:
+		for _, err := range x.Unwrap() {
+			names = groups(err, names)
+		}
+	}
+	return names
+}
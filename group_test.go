@@ -0,0 +1,48 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithGroup(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithGroup(base, "storage", io.EOF, NotFound)
+	err = wrap.WithGroup(fmt.Errorf("context: %w", err), "api", errors.New("request failed"))
+
+	if actual, expected := err.Error(), "request failed: context: not found: EOF: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if actual, expected := fmt.Sprint(wrap.Groups(err)), "[api storage]"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, io.EOF) || !errors.Is(err, NotFound) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+
+	actual := wrap.Dump(wrap.WithGroup(base, "storage", NotFound))
+	expected := `*wrap.stack "not found: some pig" group "storage"
+  *errors.errorString "not found"
+  *errors.errorString "some pig"
+`
+	if actual != expected {
+		t.Fatalf("expected\n%s\nbut got\n%s", expected, actual)
+	}
+	if actual, expected := fmt.Sprintf("%+v", wrap.WithGroup(base, "storage", NotFound)), "group \"storage\"\nnot found\nsome pig"; actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+
+	if err := wrap.WithGroup(base, "storage"); err != base {
+		t.Fatalf("expected back error unchanged but got %v", err)
+	}
+	if groups := wrap.Groups(wrap.With(base, NotFound)); groups != nil {
+		t.Fatalf("expected no groups but got %v", groups)
+	}
+	if err := wrap.WithGroup(nil, "storage", NotFound); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
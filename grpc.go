@@ -0,0 +1,20 @@
+package wrap
+
+import "errors"
+
+// GRPCStatus finds the first error in err's chain with a GRPCStatus() S
+// method, and returns the status it reports and true. If no error in the chain
+// has the method, it returns the zero value of S and false.
+//
+// To avoid depending on gRPC, the status type is a type parameter, so callers
+// using google.golang.org/grpc/status would write:
+//
+//	st, ok := wrap.GRPCStatus[*status.Status](err)
+func GRPCStatus[S any](err error) (S, bool) {
+	var g interface{ GRPCStatus() S }
+	if errors.As(err, &g) {
+		return g.GRPCStatus(), true
+	}
+	var zero S
+	return zero, false
+}
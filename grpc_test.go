@@ -0,0 +1,42 @@
+package wrap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+type fakeStatus struct {
+	code int
+}
+
+type grpcError struct {
+	status *fakeStatus
+}
+
+func (g grpcError) Error() string {
+	return "rpc error"
+}
+
+func (g grpcError) GRPCStatus() *fakeStatus {
+	return g.status
+}
+
+func TestGRPCStatus(t *testing.T) {
+	st := &fakeStatus{code: 5}
+	err := wrap.With(wrap.With(grpcError{status: st}, NotFound), ErrTxnFailed)
+	if actual, ok := wrap.GRPCStatus[*fakeStatus](err); !ok || actual != st {
+		t.Fatalf("expected %v but got %v", st, actual)
+	}
+
+	outer := &fakeStatus{code: 14}
+	err = wrap.With(err, grpcError{status: outer})
+	if actual, ok := wrap.GRPCStatus[*fakeStatus](err); !ok || actual != outer {
+		t.Fatalf("expected outermost status %v but got %v", outer, actual)
+	}
+
+	if actual, ok := wrap.GRPCStatus[*fakeStatus](errors.New("some pig")); ok || actual != nil {
+		t.Fatalf("expected no status but got %v", actual)
+	}
+}
@@ -0,0 +1,130 @@
+package wrap
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// StatusCoder is implemented by errors that carry an HTTP status code.
+type StatusCoder interface {
+	HTTPStatus() int
+}
+
+// statusError is the StatusCoder created by WithStatus.
+type statusError struct {
+	status int
+}
+
+func (e *statusError) Error() string {
+	return "HTTP " + strconv.Itoa(e.status)
+}
+
+func (e *statusError) HTTPStatus() int {
+	return e.status
+}
+
+// WithStatus returns an error that represents an error carrying the given HTTP
+// status code wrapped over back, so its message is like "HTTP 404: " followed
+// by back's message. If back is nil, the returned error is nil.
+func WithStatus(back error, status int) error {
+	return With(back, &statusError{status: status})
+}
+
+// HTTPStatus returns the status code of the first StatusCoder in err's chain
+// and true. Since the chain is searched from the outside in, the status from
+// the last call to WithStatus wins. If no error in the chain is a
+// StatusCoder, HTTPStatus returns 0 and false, and callers will usually want
+// to respond with http.StatusInternalServerError.
+func HTTPStatus(err error) (int, bool) {
+	var s StatusCoder
+	if errors.As(err, &s) {
+		return s.HTTPStatus(), true
+	}
+	return 0, false
+}
+
+// maxBodySnippet is the most bytes of a response body WithHTTPResponse will
+// record.
+const maxBodySnippet = 512
+
+// responseError is the error created by WithHTTPResponse.
+type responseError struct {
+	status int
+	body   string
+}
+
+func (e *responseError) Error() string {
+	return "response status " + strconv.Itoa(e.status)
+}
+
+func (e *responseError) HTTPResponse() (int, string) {
+	return e.status, e.body
+}
+
+// WithHTTPResponse returns an error that represents an error carrying the
+// status code and the start of the body of resp wrapped over back, so its
+// message is like "response status 502: " followed by back's message. The
+// status and body can be retrieved with HTTPResponseInfo. It is meant for
+// errors from calls to other services, so unlike WithStatus, the status isn't
+// reported by HTTPStatus. If back is nil, the returned error is nil, and if
+// resp is nil, back is returned unchanged.
+//
+// Up to 512 bytes of the body are read, and resp.Body is replaced with a
+// reader that returns those bytes again before reading the rest of the
+// original body, so the caller can still read all of it. Reading blocks until
+// those bytes have arrived or the body ends, and if reading fails, the bytes
+// read before the failure are recorded and the error is returned again by the
+// replaced body.
+func WithHTTPResponse(back error, resp *http.Response) error {
+	if back == nil {
+		return nil
+	}
+	if resp == nil {
+		return back
+	}
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodySnippet))
+		var rest io.Reader = resp.Body
+		if err != nil {
+			rest = errReader{err}
+		}
+		resp.Body = readCloser{
+			Reader: io.MultiReader(bytes.NewReader(body), rest),
+			Closer: resp.Body,
+		}
+	}
+	return With(back, &responseError{status: resp.StatusCode, body: string(body)})
+}
+
+// readCloser combines a reader with the closer of the body it replaces.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader is a reader that always fails with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// HTTPResponseInfo returns the status code and body recorded by the outermost
+// call to WithHTTPResponse in err's chain, or by any other error in it with an
+// HTTPResponse() (int, string) method, and true. If there is none, it returns
+// 0, "" and false.
+func HTTPResponseInfo(err error) (code int, body string, ok bool) {
+	var r interface{ HTTPResponse() (int, string) }
+	if errors.As(err, &r) {
+		code, body = r.HTTPResponse()
+		return code, body, true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,73 @@
+package wrap_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithStatus(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithStatus(base, http.StatusNotFound)
+	if actual, expected := err.Error(), "HTTP 404: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	var sc wrap.StatusCoder
+	if !errors.As(err, &sc) || sc.HTTPStatus() != http.StatusNotFound {
+		t.Fatal("failed to find status coder")
+	}
+
+	err = wrap.WithStatus(wrap.With(err, NotFound), http.StatusConflict)
+	if status, ok := wrap.HTTPStatus(err); !ok || status != http.StatusConflict {
+		t.Fatalf("expected outermost status 409 but got %v", status)
+	}
+	if status, ok := wrap.HTTPStatus(base); ok || status != 0 {
+		t.Fatalf("expected no status but got %v", status)
+	}
+}
+
+func TestWithHTTPResponse(t *testing.T) {
+	body := strings.Repeat("x", 600)
+	resp := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	base := errors.New("some pig")
+	err := wrap.WithHTTPResponse(base, resp)
+	if actual, expected := err.Error(), "response status 502: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	code, snippet, ok := wrap.HTTPResponseInfo(wrap.With(err, NotFound))
+	if !ok || code != http.StatusBadGateway {
+		t.Fatalf("expected status 502 but got %v", code)
+	}
+	if snippet != body[:512] {
+		t.Fatalf("expected first 512 bytes of body but got %d bytes", len(snippet))
+	}
+	if status, ok := wrap.HTTPStatus(err); ok {
+		t.Fatalf("expected response status not to be reported by HTTPStatus but got %v", status)
+	}
+
+	// The caller can still read the whole body.
+	rest, readErr := io.ReadAll(resp.Body)
+	if readErr != nil || string(rest) != body {
+		t.Fatalf("expected full body but got %d bytes, %v", len(rest), readErr)
+	}
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		t.Fatalf("unexpected error closing body: %v", closeErr)
+	}
+
+	if _, _, ok := wrap.HTTPResponseInfo(base); ok {
+		t.Fatal("expected no response info")
+	}
+	if err := wrap.WithHTTPResponse(base, nil); err != base {
+		t.Fatalf("expected back error unchanged but got %v", err)
+	}
+	if err := wrap.WithHTTPResponse(nil, resp); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
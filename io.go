@@ -0,0 +1,49 @@
+package wrap
+
+import "io"
+
+// reader is the io.Reader returned by WrapReader.
+type reader struct {
+	r     io.Reader
+	front error
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err == io.EOF {
+		return n, err
+	}
+	return n, With(err, r.front)
+}
+
+// WrapReader returns an io.Reader that reads from r, wrapping any error r
+// returns with front, as in With. The exception is io.EOF, which is returned
+// as is, since callers compare it with == to know when to stop reading. If
+// front is nil, r is returned unchanged.
+func WrapReader(r io.Reader, front error) io.Reader {
+	if front == nil {
+		return r
+	}
+	return &reader{r: r, front: front}
+}
+
+// writer is the io.Writer returned by WrapWriter.
+type writer struct {
+	w     io.Writer
+	front error
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	return n, With(err, w.front)
+}
+
+// WrapWriter returns an io.Writer that writes to w, wrapping any error w
+// returns with front, as in With. The number of bytes written is returned
+// unchanged. If front is nil, w is returned unchanged.
+func WrapWriter(w io.Writer, front error) io.Writer {
+	if front == nil {
+		return w
+	}
+	return &writer{w: w, front: front}
+}
@@ -0,0 +1,96 @@
+package wrap_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+var ErrReadConfig = errors.New("reading config")
+
+// failingReader returns the data it holds, and then err.
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	if r.data == "" {
+		return n, r.err
+	}
+	return n, nil
+}
+
+func TestWrapReader(t *testing.T) {
+	base := errors.New("some pig")
+	r := wrap.WrapReader(&failingReader{data: "terrific", err: base}, ErrReadConfig)
+	data, err := io.ReadAll(r)
+	if string(data) != "terrific" {
+		t.Fatalf("expected data to pass through but got %q", data)
+	}
+	if !errors.Is(err, ErrReadConfig) || !errors.Is(err, base) {
+		t.Fatalf("expected wrapped error but got %v", err)
+	}
+	if actual, expected := err.Error(), "reading config: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	r = wrap.WrapReader(strings.NewReader("radiant"), ErrReadConfig)
+	buf := make([]byte, 16)
+	n, err := r.Read(buf)
+	if err != nil || string(buf[:n]) != "radiant" {
+		t.Fatalf("expected data and no error but got %q, %v", buf[:n], err)
+	}
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Fatalf("expected io.EOF unchanged but got %v", err)
+	}
+
+	plain := strings.NewReader("humble")
+	if r := wrap.WrapReader(plain, nil); r != plain {
+		t.Fatalf("expected reader unchanged but got %v", r)
+	}
+}
+
+var ErrWriteLog = errors.New("writing log")
+
+// shortWriter accepts at most limit bytes, and fails when asked for more.
+type shortWriter struct {
+	limit int
+	err   error
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		return w.limit, w.err
+	}
+	return len(p), nil
+}
+
+func TestWrapWriter(t *testing.T) {
+	w := wrap.WrapWriter(&shortWriter{limit: 4, err: io.ErrShortWrite}, ErrWriteLog)
+	n, err := w.Write([]byte("terrific"))
+	if n != 4 {
+		t.Fatalf("expected 4 bytes written but got %d", n)
+	}
+	if !errors.Is(err, ErrWriteLog) || !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("expected wrapped error but got %v", err)
+	}
+	if actual, expected := err.Error(), "writing log: short write"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	n, err = w.Write([]byte("pig"))
+	if n != 3 || err != nil {
+		t.Fatalf("expected 3 bytes and no error but got %d, %v", n, err)
+	}
+
+	var b strings.Builder
+	if w := wrap.WrapWriter(&b, nil); w != &b {
+		t.Fatalf("expected writer unchanged but got %v", w)
+	}
+}
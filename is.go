@@ -0,0 +1,178 @@
+package wrap
+
+import (
+	"errors"
+	"strings"
+)
+
+// IsAny reports whether errors.Is(err, target) is true for any of targets. It
+// stops at the first match. Nil targets never match, and if there are no
+// targets, IsAny returns false.
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if target != nil && errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAll reports whether errors.Is(err, target) is true for every one of
+// targets. As with IsAny, nil targets never match, so if any target is nil,
+// IsAll returns false. If there are no targets, IsAll returns true.
+func IsAll(err error, targets ...error) bool {
+	for _, target := range targets {
+		if target == nil || !errors.Is(err, target) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of errors in err's chain, as returned by Chain,
+// that match target. An error matches if it equals target, or if it has an
+// Is(error) bool method that reports true for target. Errors are not
+// unwrapped to check them, since the errors they wrap are counted separately,
+// so fmt.Errorf("context: %w", target) only counts once. If err or target is
+// nil, Count returns 0.
+func Count(err, target error) int {
+	if err == nil || target == nil {
+		return 0
+	}
+	comparable := isComparable(target)
+	var n int
+	walk(err, func(err error) bool {
+		if matches(err, target, comparable) {
+			n++
+		}
+		return true
+	})
+	return n
+}
+
+// HasSequence reports whether targets match consecutive errors in err's chain,
+// as returned by Chain, in the same order. Each error is matched as in Count,
+// so it must itself match its target rather than wrap an error that does. Nil
+// targets never match, and if there are no targets, HasSequence returns true.
+//
+// Every error in the chain counts, including ones with empty messages, like
+// those added by WithTag. Use HasSubsequence to allow other errors between the
+// targets.
+func HasSequence(err error, targets ...error) bool {
+	return hasSequence(err, targets, false)
+}
+
+// HasSubsequence is like HasSequence, but other errors in err's chain may come
+// before, between or after the targets, so it only checks their order.
+func HasSubsequence(err error, targets ...error) bool {
+	return hasSequence(err, targets, true)
+}
+
+// hasSequence implements HasSequence and HasSubsequence. If gaps is true,
+// errors that don't match the next target are skipped.
+func hasSequence(err error, targets []error, gaps bool) bool {
+	if len(targets) == 0 {
+		return true
+	}
+	comparable := make([]bool, len(targets))
+	for i, target := range targets {
+		if target == nil {
+			return false
+		}
+		comparable[i] = isComparable(target)
+	}
+	chain := Chain(err)
+	if gaps {
+		// Matching greedily finds the targets in order if anything does.
+		j := 0
+		for _, err := range chain {
+			if j < len(targets) && matches(err, targets[j], comparable[j]) {
+				j++
+			}
+		}
+		return j == len(targets)
+	}
+	for start := 0; start+len(targets) <= len(chain); start++ {
+		j := 0
+		for j < len(targets) && matches(chain[start+j], targets[j], comparable[j]) {
+			j++
+		}
+		if j == len(targets) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether err itself matches target, as in errors.Is, but
+// without unwrapping err. The caller says whether target is comparable, so it
+// can be checked once for a whole chain.
+func matches(err, target error, comparable bool) bool {
+	if comparable && err == target {
+		return true
+	}
+	x, ok := err.(interface{ Is(error) bool })
+	return ok && x.Is(target)
+}
+
+// Equal reports whether a and b are structurally equivalent. They are if
+// Chain returns the same number of errors for each, and each pair of errors at
+// the same position has the same message and either:
+//
+//   - matches each other with errors.Is in both directions, as sentinel errors
+//     do when they are the same value, or
+//   - has the same type and wraps other errors, like two errors from
+//     fmt.Errorf with the same message. The errors they wrap are compared
+//     later in the chain.
+//
+// Two nil errors are equal, but nil is not equal to any other error.
+func Equal(a, b error) bool {
+	ca, cb := Chain(a), Chain(b)
+	if len(ca) != len(cb) {
+		return false
+	}
+	for i := range ca {
+		if !equalLayer(ca[i], cb[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalLayer reports whether a and b are equivalent layers of a chain, as
+// described in Equal.
+func equalLayer(a, b error) bool {
+	if a.Error() != b.Error() {
+		return false
+	}
+	if errors.Is(a, b) && errors.Is(b, a) {
+		return true
+	}
+	return sameType(a, b) && wraps(a)
+}
+
+// wraps reports whether err wraps one or more other errors.
+func wraps(err error) bool {
+	switch err.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+		return true
+	}
+	return false
+}
+
+// Contains reports whether the message of any error in err's chain, as
+// returned by Chain, contains substr. Each error's own message is checked, not
+// the message of a stack, so substr can't match across the separator between
+// two wrapped errors.
+//
+// Matching on messages is fragile, so Contains is a last resort for errors
+// that can't be matched any other way, such as those from libraries that only
+// return errors made with errors.New or fmt.Errorf.
+func Contains(err error, substr string) bool {
+	var found bool
+	walk(err, func(err error) bool {
+		found = strings.Contains(err.Error(), substr)
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,155 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestIsAny(t *testing.T) {
+	err := wrap.With(errors.New("some pig"), NotFound)
+	if !wrap.IsAny(err, io.EOF, NotFound) {
+		t.Fatal("failed to find flag")
+	}
+	if wrap.IsAny(err, io.EOF, io.ErrClosedPipe) {
+		t.Fatal("unexpectedly matched")
+	}
+	if wrap.IsAny(err) {
+		t.Fatal("unexpectedly matched empty targets")
+	}
+	if wrap.IsAny(nil, nil) {
+		t.Fatal("unexpectedly matched nil target")
+	}
+}
+
+var (
+	ErrTimeout   = errors.New("timeout")
+	ErrRetryable = errors.New("retryable")
+)
+
+func TestIsAll(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithAll(base, ErrTimeout, ErrRetryable)
+	if !wrap.IsAll(err, ErrTimeout, ErrRetryable) {
+		t.Fatal("failed to find both flags")
+	}
+	if wrap.IsAll(wrap.With(base, ErrTimeout), ErrTimeout, ErrRetryable) {
+		t.Fatal("unexpectedly matched with a flag missing")
+	}
+	if !wrap.IsAll(err) {
+		t.Fatal("expected empty targets to match")
+	}
+	if wrap.IsAll(err, ErrTimeout, nil) {
+		t.Fatal("unexpectedly matched nil target")
+	}
+}
+
+// isNotFound matches NotFound via its Is method.
+type isNotFound struct{}
+
+func (isNotFound) Error() string {
+	return "is not found"
+}
+
+func (isNotFound) Is(target error) bool {
+	return target == NotFound
+}
+
+func TestCount(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.With(base, NotFound)
+	err = wrap.With(err, fmt.Errorf("flagged: %w", NotFound))
+	err = wrap.With(err, isNotFound{})
+
+	if n := wrap.Count(err, NotFound); n != 3 {
+		t.Fatalf("expected 3 matches but got %d", n)
+	}
+	if n := wrap.Count(err, base); n != 1 {
+		t.Fatalf("expected 1 match but got %d", n)
+	}
+	if n := wrap.Count(err, io.EOF); n != 0 {
+		t.Fatalf("expected no matches but got %d", n)
+	}
+	if n := wrap.Count(nil, NotFound); n != 0 {
+		t.Fatalf("expected no matches but got %d", n)
+	}
+	if n := wrap.Count(err, nil); n != 0 {
+		t.Fatalf("expected no matches but got %d", n)
+	}
+}
+
+func TestHasSequence(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.With(wrap.With(base, NotFound), fmt.Errorf("flagged: %w", io.EOF))
+	err = wrap.WithTag(err, "barn")
+
+	tests := []struct {
+		targets     []error
+		sequence    bool
+		subsequence bool
+	}{
+		{nil, true, true},
+		{[]error{io.EOF, NotFound, base}, true, true},
+		{[]error{NotFound, base}, true, true},
+		{[]error{NotFound, io.EOF}, false, false},
+		{[]error{base, NotFound}, false, false},
+		{[]error{io.EOF, base}, false, true},
+		{[]error{io.EOF, io.EOF}, false, false},
+		{[]error{ErrTimeout}, false, false},
+		{[]error{NotFound, nil}, false, false},
+	}
+	for _, test := range tests {
+		if actual := wrap.HasSequence(err, test.targets...); actual != test.sequence {
+			t.Errorf("HasSequence(%v): expected %v but got %v", test.targets, test.sequence, actual)
+		}
+		if actual := wrap.HasSubsequence(err, test.targets...); actual != test.subsequence {
+			t.Errorf("HasSubsequence(%v): expected %v but got %v", test.targets, test.subsequence, actual)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	base := errors.New("some pig")
+	build := func() error {
+		return wrap.With(wrap.With(fmt.Errorf("wilbur: %w", base), NotFound), io.EOF)
+	}
+
+	tests := []struct {
+		a, b     error
+		expected bool
+	}{
+		{build(), build(), true},
+		{nil, nil, true},
+		{base, base, true},
+		{build(), nil, false},
+		{base, errors.New("some pig"), false},
+		{build(), wrap.With(wrap.With(fmt.Errorf("wilbur: %w", base), NotFound), io.ErrUnexpectedEOF), false},
+		{build(), wrap.With(wrap.With(fmt.Errorf("wilbur: %w", errors.New("some pig")), NotFound), io.EOF), false},
+		{build(), wrap.With(fmt.Errorf("wilbur: %w", base), io.EOF), false},
+		{build(), wrap.With(wrap.With(fmt.Errorf("charlotte: %w", base), NotFound), io.EOF), false},
+	}
+	for i, test := range tests {
+		if actual := wrap.Equal(test.a, test.b); actual != test.expected {
+			t.Errorf("%d: expected %v but got %v", i, test.expected, actual)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	err := wrap.With(wrap.With(errors.New("dial tcp: connection refused"), NotFound), io.EOF)
+	if !wrap.Contains(err, "connection refused") {
+		t.Fatal("failed to find substring in back error")
+	}
+	if wrap.Contains(err, "found: dial") {
+		t.Fatal("unexpectedly matched across separator")
+	}
+	if wrap.Contains(err, "timeout") {
+		t.Fatal("unexpectedly matched missing substring")
+	}
+	if wrap.Contains(nil, "") {
+		t.Fatal("unexpectedly matched nil error")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build go1.23
+
+package wrap
+
+import "iter"
+
+// Iter returns an iterator over the errors in err's chain. It yields the same
+// errors in the same order as Chain, but stops walking the chain as soon as
+// the loop ends.
+func Iter(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		walk(err, yield)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build go1.23
+
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestIter(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", base)
+	err := wrap.With(wrapped, NotFound)
+
+	var actual []error
+	for e := range wrap.Iter(err) {
+		actual = append(actual, e)
+	}
+	expected := []error{NotFound, wrapped, base}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected %v at index %d but got %v", expected[i], i, actual[i])
+		}
+	}
+
+	var count int
+	for range wrap.Iter(err) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Fatalf("expected iteration to stop after 1 error but got %d", count)
+	}
+
+	for e := range wrap.Iter(nil) {
+		t.Fatalf("expected no errors but got %v", e)
+	}
+}
@@ -0,0 +1,33 @@
+package wrap
+
+import "encoding/json"
+
+// MarshalJSON implements json.Marshaler. The error is marshaled as an object
+// with the same message as Error under "message", and the errors that make up
+// the stack under "causes", front first. Each cause that implements
+// json.Marshaler is marshaled by its own method, and any other cause is
+// marshaled as its message.
+func (s *stack) MarshalJSON() ([]byte, error) {
+	leaves := flatten(s, nil)
+	causes := make([]interface{}, len(leaves))
+	for i, err := range leaves {
+		if _, ok := err.(json.Marshaler); ok {
+			causes[i] = err
+		} else {
+			causes[i] = err.Error()
+		}
+	}
+	return json.Marshal(struct {
+		Message string        `json:"message"`
+		Causes  []interface{} `json:"causes"`
+	}{
+		Message: s.Error(),
+		Causes:  causes,
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the same message as
+// Error. It never returns an error.
+func (s *stack) MarshalText() ([]byte, error) {
+	return []byte(s.Error()), nil
+}
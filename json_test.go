@@ -0,0 +1,63 @@
+package wrap_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+type jsonError struct {
+	Code int
+}
+
+func (j jsonError) Error() string {
+	return "json error"
+}
+
+func (j jsonError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code int `json:"code"`
+	}{j.Code})
+}
+
+func TestMarshalJSON(t *testing.T) {
+	err := wrap.With(errors.New("some pig"), NotFound)
+	b, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+	actual := string(b)
+	expected := `{"message":"not found: some pig","causes":["not found","some pig"]}`
+	if actual != expected {
+		t.Fatalf("expected %s but got %s", expected, actual)
+	}
+
+	err = wrap.With(err, jsonError{Code: 404})
+	b, jerr = json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+	actual = string(b)
+	expected = `{"message":"json error: not found: some pig","causes":[{"code":404},"not found","some pig"]}`
+	if actual != expected {
+		t.Fatalf("expected %s but got %s", expected, actual)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	err := wrap.With(errors.New("some pig"), NotFound)
+	tm, ok := err.(encoding.TextMarshaler)
+	if !ok {
+		t.Fatal("expected error to implement encoding.TextMarshaler")
+	}
+	b, terr := tm.MarshalText()
+	if terr != nil {
+		t.Fatal(terr)
+	}
+	if string(b) != err.Error() {
+		t.Fatalf("expected %q but got %q", err.Error(), b)
+	}
+}
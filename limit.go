@@ -0,0 +1,46 @@
+package wrap
+
+import "errors"
+
+// MaxDepth, if greater than zero, limits how deep a chain With and the other
+// functions in this package that wrap one error over another will build, as
+// measured by Depth. When wrapping front over back would make a chain deeper
+// than MaxDepth, back is returned marked with ErrMaxDepthExceeded instead,
+// and if back is already marked, it is returned unchanged. This stops
+// wrapping in a loop from growing a chain without bound.
+//
+// Checking the limit walks back's chain on every call, so MaxDepth defaults
+// to zero, meaning no limit. Like DefaultSeparator, it should only be set
+// during program initialization.
+var MaxDepth int
+
+// ErrMaxDepthExceeded marks errors that stopped growing because of MaxDepth.
+var ErrMaxDepthExceeded = errors.New("maximum error depth exceeded")
+
+// limitDepth returns the error to use in place of wrapping front over back and
+// true, if doing so would exceed MaxDepth. Otherwise, it returns nil and
+// false.
+func limitDepth(back, front error) (error, bool) {
+	if MaxDepth <= 0 || !deeperThan(MaxDepth, back, front) {
+		return nil, false
+	}
+	if errors.Is(back, ErrMaxDepthExceeded) {
+		return back, true
+	}
+	return newStack(ErrMaxDepthExceeded, back), true
+}
+
+// deeperThan reports whether the combined depth of errs is more than n. It
+// stops walking as soon as it knows.
+func deeperThan(n int, errs ...error) bool {
+	for _, err := range errs {
+		walk(err, func(error) bool {
+			n--
+			return n >= 0
+		})
+		if n < 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestMaxDepth(t *testing.T) {
+	defer func(n int) { wrap.MaxDepth = n }(wrap.MaxDepth)
+	wrap.MaxDepth = 3
+
+	var err error = errors.New("some pig")
+	for i := 0; i < 10; i++ {
+		err = wrap.With(err, fmt.Errorf("flag %d", i))
+	}
+	if depth := wrap.Depth(err); depth != 4 {
+		t.Fatalf("expected chain to stop growing at depth 4 but got %d", depth)
+	}
+	if n := wrap.Count(err, wrap.ErrMaxDepthExceeded); n != 1 {
+		t.Fatalf("expected ErrMaxDepthExceeded once but found it %d times", n)
+	}
+	if actual, expected := err.Error(), "maximum error depth exceeded: flag 1: flag 0: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	err = wrap.WithSep(err, NotFound, " | ")
+	if depth := wrap.Depth(err); depth != 4 {
+		t.Fatalf("expected WithSep to respect MaxDepth but got depth %d", depth)
+	}
+}
+
+func TestMaxDepthUnlimited(t *testing.T) {
+	var err error = errors.New("some pig")
+	for i := 0; i < 100; i++ {
+		err = wrap.With(err, NotFound)
+	}
+	if depth := wrap.Depth(err); depth != 101 {
+		t.Fatalf("expected depth 101 but got %d", depth)
+	}
+}
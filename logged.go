@@ -0,0 +1,33 @@
+package wrap
+
+// loggedError is the marker wrapped over errors by MarkLogged. Its message is
+// empty, so it doesn't change the message of the error it is wrapped over.
+type loggedError struct{}
+
+func (*loggedError) Error() string {
+	return ""
+}
+
+// logged is the only loggedError, since the marker carries no data.
+var logged = &loggedError{}
+
+// MarkLogged returns err with a marker wrapped over it recording that it has
+// been logged, which can be checked with IsLogged. The returned error's message
+// is the same as err's. If err is nil, the returned error is nil, and if err is
+// already marked, it is returned unchanged.
+//
+// This lets logging middleware in layered handlers skip errors that were
+// already logged further down the call stack.
+func MarkLogged(err error) error {
+	if IsLogged(err) {
+		return err
+	}
+	return With(err, logged)
+}
+
+// IsLogged reports whether any error in err's chain was marked by MarkLogged.
+func IsLogged(err error) bool {
+	return !walk(err, func(err error) bool {
+		return err != logged
+	})
+}
@@ -0,0 +1,37 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestMarkLogged(t *testing.T) {
+	base := errors.New("some pig")
+	if wrap.IsLogged(base) {
+		t.Fatal("expected unmarked error not to be logged")
+	}
+	err := wrap.MarkLogged(base)
+	if actual, expected := err.Error(), "some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !wrap.IsLogged(err) {
+		t.Fatal("expected marked error to be logged")
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+
+	err = fmt.Errorf("context: %w", wrap.With(err, NotFound))
+	if !wrap.IsLogged(err) {
+		t.Fatal("expected marker to survive further wrapping")
+	}
+	if again := wrap.MarkLogged(err); again != err {
+		t.Fatalf("expected already marked error to be returned unchanged but got %v", again)
+	}
+	if err := wrap.MarkLogged(nil); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package wrap
+
+import (
+	"errors"
+	"strings"
+)
+
+// MatchOption is a condition an error must meet to satisfy a predicate from
+// Match.
+type MatchOption func(error) bool
+
+// Match returns a predicate that reports whether an error meets all of the
+// given conditions. With no conditions, the predicate is always true. It is
+// meant for table-driven tests and assertion libraries that take a func(error)
+// bool.
+func Match(opts ...MatchOption) func(error) bool {
+	return func(err error) bool {
+		for _, opt := range opts {
+			if !opt(err) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// HasSentinel requires errors.Is(err, target) to be true.
+func HasSentinel(target error) MatchOption {
+	return func(err error) bool {
+		return errors.Is(err, target)
+	}
+}
+
+// HasType requires an error in err's chain to be a T, as found by AsType.
+func HasType[T error]() MatchOption {
+	return func(err error) bool {
+		_, ok := AsType[T](err)
+		return ok
+	}
+}
+
+// MessageContains requires err's message to contain s. Unlike Contains, the
+// whole message is checked.
+func MessageContains(s string) MatchOption {
+	return func(err error) bool {
+		return err != nil && strings.Contains(err.Error(), s)
+	}
+}
+
+// HasDepth requires err's chain to have exactly n errors, as counted by Depth.
+func HasDepth(n int) MatchOption {
+	return func(err error) bool {
+		return Depth(err) == n
+	}
+}
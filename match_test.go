@@ -0,0 +1,53 @@
+package wrap_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestMatch(t *testing.T) {
+	isNotFoundPig := wrap.Match(wrap.HasSentinel(NotFound), wrap.MessageContains("pig"))
+
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{wrap.With(errors.New("some pig"), NotFound), true},
+		{wrap.With(errors.New("some cow"), NotFound), false},
+		{wrap.With(errors.New("some pig"), io.EOF), false},
+		{nil, false},
+	}
+	for _, test := range tests {
+		if actual := isNotFoundPig(test.err); actual != test.expected {
+			t.Errorf("%v: expected %v but got %v", test.err, test.expected, actual)
+		}
+	}
+}
+
+func TestMatchOptions(t *testing.T) {
+	err := wrap.With(myError("some pig"), NotFound)
+
+	tests := []struct {
+		name     string
+		opt      wrap.MatchOption
+		expected bool
+	}{
+		{"HasType", wrap.HasType[myError](), true},
+		{"HasType missing", wrap.HasType[otherError](), false},
+		{"HasDepth", wrap.HasDepth(2), true},
+		{"HasDepth wrong", wrap.HasDepth(3), false},
+		{"MessageContains", wrap.MessageContains("found: some"), true},
+		{"HasSentinel", wrap.HasSentinel(io.EOF), false},
+	}
+	for _, test := range tests {
+		if actual := wrap.Match(test.opt)(err); actual != test.expected {
+			t.Errorf("%s: expected %v but got %v", test.name, test.expected, actual)
+		}
+	}
+	if !wrap.Match()(err) {
+		t.Error("expected empty matcher to match")
+	}
+}
@@ -0,0 +1,31 @@
+package wrap
+
+import "errors"
+
+// Errors returned by With don't have Timeout or Temporary methods, even when
+// they wrap a net.Error. A method set can't depend on the values a stack holds,
+// and exposing the methods on every stack would let a type assertion to
+// net.Error succeed when neither wrapped error is one. Instead, Timeout and
+// Temporary search the chain for the methods.
+
+// Timeout finds the first error in err's chain with a Timeout() bool method,
+// such as a net.Error, and returns its result and true. If no error in the
+// chain has the method, Timeout returns false and false.
+func Timeout(err error) (timeout, ok bool) {
+	var t interface{ Timeout() bool }
+	if errors.As(err, &t) {
+		return t.Timeout(), true
+	}
+	return false, false
+}
+
+// Temporary finds the first error in err's chain with a Temporary() bool
+// method, such as a net.Error, and returns its result and true. If no error in
+// the chain has the method, Temporary returns false and false.
+func Temporary(err error) (temporary, ok bool) {
+	var t interface{ Temporary() bool }
+	if errors.As(err, &t) {
+		return t.Temporary(), true
+	}
+	return false, false
+}
@@ -0,0 +1,46 @@
+package wrap_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+type netError struct {
+	timeout, temporary bool
+}
+
+func (n netError) Error() string   { return "net error" }
+func (n netError) Timeout() bool   { return n.timeout }
+func (n netError) Temporary() bool { return n.temporary }
+
+var _ net.Error = netError{}
+
+func TestTimeout(t *testing.T) {
+	err := wrap.With(netError{timeout: true}, NotFound)
+	if _, ok := err.(net.Error); ok {
+		t.Fatal("expected stack not to implement net.Error")
+	}
+	if timeout, ok := wrap.Timeout(err); !ok || !timeout {
+		t.Fatalf("expected timeout but got %v, %v", timeout, ok)
+	}
+	err = wrap.With(err, netError{})
+	if timeout, ok := wrap.Timeout(err); !ok || timeout {
+		t.Fatalf("expected outermost net error to win but got %v, %v", timeout, ok)
+	}
+	if timeout, ok := wrap.Timeout(wrap.With(errors.New("some pig"), NotFound)); ok || timeout {
+		t.Fatalf("expected no timeout but got %v, %v", timeout, ok)
+	}
+}
+
+func TestTemporary(t *testing.T) {
+	err := wrap.With(netError{temporary: true}, NotFound)
+	if temporary, ok := wrap.Temporary(err); !ok || !temporary {
+		t.Fatalf("expected temporary but got %v, %v", temporary, ok)
+	}
+	if temporary, ok := wrap.Temporary(errors.New("some pig")); ok || temporary {
+		t.Fatalf("expected not temporary but got %v, %v", temporary, ok)
+	}
+}
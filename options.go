@@ -0,0 +1,55 @@
+package wrap
+
+// Option configures the error returned by WithOpts.
+type Option func(*options)
+
+// options holds the configuration that each Option passed to WithOpts sets.
+type options struct {
+	sep      string
+	trace    bool
+	reversed bool
+}
+
+// UseSeparator makes WithOpts separate the front and back messages with sep,
+// as in WithSep.
+func UseSeparator(sep string) Option {
+	return func(o *options) {
+		o.sep = sep
+	}
+}
+
+// RecordStack makes WithOpts record the stack of the function that called it,
+// as in WithStack.
+func RecordStack() Option {
+	return func(o *options) {
+		o.trace = true
+	}
+}
+
+// ReverseMessage makes WithOpts write the back message before the front one,
+// as in WithReversed.
+func ReverseMessage() Option {
+	return func(o *options) {
+		o.reversed = true
+	}
+}
+
+// WithOpts is like With, but the returned error is configured by opts. With no
+// options, it is the same as With.
+func WithOpts(back, front error, opts ...Option) error {
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	o := options{sep: DefaultSeparator}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	s := makeStack(front, back, comparable)
+	s.sep = o.sep
+	s.reversed = o.reversed
+	if o.trace {
+		s.trace = callers(3)
+	}
+	return &s
+}
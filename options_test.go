@@ -0,0 +1,52 @@
+package wrap_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithOpts(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithOpts(base, NotFound)
+	if actual, expected := err.Error(), "not found: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if tr := err.(tracer); tr.StackTrace() != nil {
+		t.Fatal("expected no stack trace without RecordStack")
+	}
+	if err := wrap.WithOpts(nil, NotFound); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestUseSeparator(t *testing.T) {
+	err := wrap.WithOpts(errors.New("some pig"), NotFound, wrap.UseSeparator(" -> "))
+	if actual, expected := err.Error(), "not found -> some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestReverseMessage(t *testing.T) {
+	err := wrap.WithOpts(errors.New("some pig"), NotFound, wrap.ReverseMessage(), wrap.UseSeparator(" <- "))
+	if actual, expected := err.Error(), "some pig <- not found"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestRecordStack(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithOpts(base, NotFound, wrap.RecordStack(), wrap.UseSeparator(" | "))
+	if actual, expected := err.Error(), "not found | some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, NotFound) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	frame, _ := err.(tracer).Frames().Next()
+	if !strings.HasSuffix(frame.Function, ".TestRecordStack") {
+		t.Fatalf("expected first frame in TestRecordStack but got %v", frame.Function)
+	}
+}
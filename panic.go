@@ -0,0 +1,73 @@
+package wrap
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// panicError is the error created from a recovered panic value by WrapPanic
+// and RecoverInto. It records the stack where the panic was recovered, which
+// still includes the frames of the function that panicked.
+type panicError struct {
+	val   interface{}
+	trace []uintptr
+}
+
+// Error returns a message like "panic: " followed by the panic value.
+func (e *panicError) Error() string {
+	return "panic: " + fmt.Sprint(e.val)
+}
+
+// Unwrap returns the panic value if it is an error, so that errors.Is and
+// errors.As can find it, and nil otherwise.
+func (e *panicError) Unwrap() error {
+	err, _ := e.val.(error)
+	return err
+}
+
+// Value returns the value the panic was called with.
+func (e *panicError) Value() interface{} {
+	return e.val
+}
+
+// StackTrace returns the program counters recorded when the panic was
+// recovered, like the StackTrace method of errors from WithStack.
+func (e *panicError) StackTrace() []uintptr {
+	return e.trace
+}
+
+// Frames returns the frames of the stack recorded when the panic was
+// recovered.
+func (e *panicError) Frames() *runtime.Frames {
+	return runtime.CallersFrames(e.trace)
+}
+
+// WrapPanic returns an error that represents front wrapped over an error made
+// from recovered, a value returned by recover, as in With. The error made from
+// recovered has a message like "panic: " followed by the value, and records
+// the stack of the function that called WrapPanic, which should be called in
+// the same deferred function that recovered. If the value is an error, it is
+// wrapped, so it can be found with errors.Is and errors.As. If recovered is
+// nil, the returned error is nil.
+func WrapPanic(recovered interface{}, front error) error {
+	if recovered == nil {
+		return nil
+	}
+	return With(&panicError{val: recovered, trace: callers(3)}, front)
+}
+
+// RecoverInto recovers from a panic and replaces the error errp points to with
+// an error made from the panic value, as in WrapPanic. It must be deferred
+// directly, so that it can recover:
+//
+//	func (w *Worker) Run() (err error) {
+//		defer wrap.RecoverInto(&err)
+//		...
+//	}
+//
+// If there is no panic, *errp is left unchanged.
+func RecoverInto(errp *error) {
+	if r := recover(); r != nil {
+		*errp = &panicError{val: r, trace: callers(3)}
+	}
+}
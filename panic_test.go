@@ -0,0 +1,105 @@
+package wrap_test
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+var ErrWorkerFailed = errors.New("worker failed")
+
+func panicky(val interface{}) {
+	panic(val)
+}
+
+func supervise(val interface{}) (err error) {
+	defer func() {
+		err = wrap.WrapPanic(recover(), ErrWorkerFailed)
+	}()
+	panicky(val)
+	return nil
+}
+
+func run(val interface{}) (err error) {
+	defer wrap.RecoverInto(&err)
+	panicky(val)
+	return nil
+}
+
+// hasFrame reports whether an error in err's chain has a recorded stack
+// including a frame from a function whose name ends with suffix.
+func hasFrame(err error, suffix string) bool {
+	for _, err := range wrap.Chain(err) {
+		tr, ok := err.(tracer)
+		if !ok || len(tr.StackTrace()) == 0 {
+			continue
+		}
+		frames := tr.Frames()
+		for {
+			frame, more := frames.Next()
+			if strings.HasSuffix(frame.Function, suffix) {
+				return true
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return false
+}
+
+func TestWrapPanic(t *testing.T) {
+	base := errors.New("some pig")
+	tests := []struct {
+		val      interface{}
+		expected string
+	}{
+		{base, "worker failed: panic: some pig"},
+		{"oops", "worker failed: panic: oops"},
+		{5, "worker failed: panic: 5"},
+	}
+	for _, test := range tests {
+		err := supervise(test.val)
+		if actual := err.Error(); actual != test.expected {
+			t.Errorf("expected %v but got %v", test.expected, actual)
+		}
+		if !errors.Is(err, ErrWorkerFailed) {
+			t.Errorf("%v: failed to find front error", test.val)
+		}
+		if !hasFrame(err, ".panicky") {
+			t.Errorf("%v: expected stack to include the panicking function", test.val)
+		}
+	}
+	if err := supervise(base); !errors.Is(err, base) {
+		t.Fatal("failed to find panic value")
+	}
+	if err := wrap.WrapPanic(nil, ErrWorkerFailed); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestRecoverInto(t *testing.T) {
+	err := run("oops")
+	if actual, expected := err.Error(), "panic: oops"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !hasFrame(err, ".panicky") {
+		t.Fatal("expected stack to include the panicking function")
+	}
+
+	err = run(&runtime.TypeAssertionError{})
+	var rerr runtime.Error
+	if !errors.As(err, &rerr) {
+		t.Fatal("failed to find runtime error")
+	}
+	base := errors.New("some pig")
+	if err := func() (err error) {
+		defer wrap.RecoverInto(&err)
+		return base
+	}(); err != base {
+		t.Fatalf("expected error to be unchanged but got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package wrap
+
+import "sync"
+
+// stackPool holds stacks released by the functions returned from WithPooled.
+var stackPool sync.Pool
+
+// WithPooled is like With, but the returned error may reuse the memory of an
+// error previously returned by WithPooled, to reduce pressure on the garbage
+// collector in programs that create and discard many errors. It also returns
+// a function that releases the error back to the pool.
+//
+// Calling the release function is optional, but once it has been called, the
+// error must not be used again, and neither may any error that wraps it or
+// was unwrapped from it, since its memory may already belong to another
+// error. The release function must be called at most once. Only release an
+// error whose every use is known, such as one that is created, checked and
+// logged within a single function. When With would return back or nil
+// without wrapping, WithPooled does the same, and its release function does
+// nothing.
+func WithPooled(back, front error) (error, func()) {
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err, noRelease
+	}
+	s, _ := stackPool.Get().(*stack)
+	if s == nil {
+		s = &stack{}
+		// The release function is made once per stack and kept through
+		// resets, so releasing doesn't allocate.
+		s.release = func() {
+			*s = stack{release: s.release}
+			stackPool.Put(s)
+		}
+	}
+	release := s.release
+	*s = makeStack(front, back, comparable)
+	s.release = release
+	return s, release
+}
+
+// noRelease is the release function for errors that weren't pooled.
+func noRelease() {}
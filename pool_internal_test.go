@@ -0,0 +1,50 @@
+package wrap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithPooledReset(t *testing.T) {
+	base := errors.New("some pig")
+	flag := errors.New("flag")
+	err, release := WithPooled(base, flag)
+	if actual, expected := err.Error(), "flag: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, flag) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+
+	s := err.(*stack)
+	release()
+	if s.front != nil || s.back != nil || s.comparable || s.sep != "" || s.trace != nil {
+		t.Fatalf("expected released stack to be reset but got %#v", *s)
+	}
+	if s.release == nil {
+		t.Fatal("expected released stack to keep its release function")
+	}
+}
+
+func TestWithPooledNil(t *testing.T) {
+	base := errors.New("some pig")
+	err, release := WithPooled(nil, base)
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	release()
+	err, release = WithPooled(base, nil)
+	if err != base {
+		t.Fatalf("expected original error but got %v", err)
+	}
+	release()
+}
+
+func TestRebuildClearsRelease(t *testing.T) {
+	err, release := WithPooled(errors.New("some pig"), errors.New("flag"))
+	defer release()
+	stacks, back := spine(err)
+	if copied := rebuild(stacks, back).(*stack); copied.release != nil {
+		t.Fatal("expected copied stack not to be pooled")
+	}
+}
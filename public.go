@@ -0,0 +1,37 @@
+package wrap
+
+import "errors"
+
+// publicError is the error created by WithPublic.
+type publicError struct {
+	msg string
+}
+
+func (e *publicError) Error() string {
+	return e.msg
+}
+
+func (e *publicError) PublicMessage() string {
+	return e.msg
+}
+
+// WithPublic returns an error that represents an error carrying a message that
+// is safe to show to users wrapped over back. The returned error's message
+// still includes back's message, so it can be logged as usual, while
+// PublicMessage returns just msg. If back is nil, the returned error is nil.
+func WithPublic(back error, msg string) error {
+	return With(back, &publicError{msg: msg})
+}
+
+// PublicMessage returns the message of the first error in err's chain with a
+// PublicMessage() string method, such as one added by WithPublic, and true.
+// Since the chain is searched from the outside in, the message from the last
+// call to WithPublic wins. If no error in the chain has a public message,
+// PublicMessage returns "" and false.
+func PublicMessage(err error) (string, bool) {
+	var p interface{ PublicMessage() string }
+	if errors.As(err, &p) {
+		return p.PublicMessage(), true
+	}
+	return "", false
+}
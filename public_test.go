@@ -0,0 +1,29 @@
+package wrap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithPublic(t *testing.T) {
+	base := errors.New("pq: connection refused on 10.0.0.1")
+	err := wrap.With(wrap.WithPublic(base, "the database is unavailable"), NotFound)
+
+	if actual, expected := err.Error(), "not found: the database is unavailable: pq: connection refused on 10.0.0.1"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	msg, ok := wrap.PublicMessage(err)
+	if !ok || msg != "the database is unavailable" {
+		t.Fatalf("expected public message but got %q", msg)
+	}
+
+	err = wrap.WithPublic(err, "try again later")
+	if msg, _ := wrap.PublicMessage(err); msg != "try again later" {
+		t.Fatalf("expected outermost public message but got %q", msg)
+	}
+	if msg, ok := wrap.PublicMessage(base); ok || msg != "" {
+		t.Fatalf("expected no public message but got %q", msg)
+	}
+}
@@ -0,0 +1,139 @@
+package wrap
+
+import "errors"
+
+// spine returns the stacks found by following back from err, outermost first,
+// and the first back error that isn't a stack. If err isn't a stack, spine
+// returns no stacks and err itself.
+func spine(err error) ([]*stack, error) {
+	var stacks []*stack
+	for {
+		s, ok := err.(*stack)
+		if !ok {
+			return stacks, err
+		}
+		stacks = append(stacks, s)
+		err = s.back
+	}
+}
+
+// rebuild wraps the front errors of stacks over back, innermost first, so
+// that rebuild(spine(err)) returns an error equivalent to err. Each new stack
+// is a copy of the old one with just its back error replaced, so it keeps the
+// same separator and stack trace, but is never pooled.
+func rebuild(stacks []*stack, back error) error {
+	for i := len(stacks) - 1; i >= 0; i-- {
+		s := *stacks[i]
+		s.back = back
+		s.release = nil
+		back = &s
+	}
+	return back
+}
+
+// Dedup returns err with duplicate front errors removed. Following the back
+// errors from err, any stack whose front error matches, via errors.Is, the
+// front of a stack closer to the outside is dropped, and the remaining stacks
+// are wrapped back over the innermost back error in the same order. If err
+// isn't a stack, it is returned unchanged.
+func Dedup(err error) error {
+	stacks, back := spine(err)
+	kept := make([]*stack, 0, len(stacks))
+outer:
+	for _, s := range stacks {
+		for _, k := range kept {
+			if errors.Is(s.front, k.front) {
+				continue outer
+			}
+		}
+		kept = append(kept, s)
+	}
+	if len(kept) == len(stacks) {
+		return err
+	}
+	return rebuild(kept, back)
+}
+
+// Without returns err with every stack whose front error matches target, via
+// errors.Is, removed, undoing the calls to With that added it. Following the
+// back errors from err, the remaining stacks are wrapped back over the
+// innermost back error in the same order. The innermost back error is always
+// kept, even if it matches target, and if err isn't a stack, it is returned
+// unchanged.
+func Without(err, target error) error {
+	stacks, back := spine(err)
+	kept := make([]*stack, 0, len(stacks))
+	for _, s := range stacks {
+		if !errors.Is(s.front, target) {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == len(stacks) {
+		return err
+	}
+	return rebuild(kept, back)
+}
+
+// Map returns err with each of the errors that make it up, as returned by
+// Flatten, replaced by the result of calling fn on it. The stacks around them
+// are rebuilt with the same structure, separators and stack traces. If fn
+// returns its argument, that error is kept as is, and if it returns nil, that
+// error is dropped, leaving the other error from the stack it was in in the
+// stack's place. If err isn't a stack, Map returns fn(err), and if err is nil,
+// Map returns nil without calling fn.
+func Map(err error, fn func(error) error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := err.(*stack)
+	if !ok {
+		return fn(err)
+	}
+	front, back := Map(s.front, fn), Map(s.back, fn)
+	switch {
+	case front == nil:
+		return back
+	case back == nil:
+		return front
+	case isComparable(front) && front == s.front && isComparable(back) && back == s.back:
+		// Uncomparable errors can't be checked with ==, so stacks holding
+		// them are always rebuilt, even if fn kept them.
+		return s
+	}
+	m := makeStack(front, back, isComparable(front))
+	m.sep = s.sep
+	m.reversed = s.reversed
+	m.group = s.group
+	m.trace = s.trace
+	return &m
+}
+
+// ReplaceRoot returns err with the innermost back error, found by following
+// back errors from err, replaced by root. All the front errors stacked over it
+// are kept in the same order. If err isn't a stack, ReplaceRoot returns root,
+// and if root is nil, it returns nil, just as With does for a nil back error.
+func ReplaceRoot(err, root error) error {
+	if root == nil {
+		return nil
+	}
+	stacks, _ := spine(err)
+	return rebuild(stacks, root)
+}
+
+// Clone returns a copy of err in which every stack that makes it up, as
+// returned by With and the like, is a new value with the same separator, stack
+// trace and other settings. The errors in the stacks, as returned by Flatten,
+// are shared with err rather than copied, so an error from fmt.Errorf that
+// wraps a stack shares that stack too. If err isn't a stack, it is returned
+// unchanged.
+func Clone(err error) error {
+	s, ok := err.(*stack)
+	if !ok {
+		return err
+	}
+	c := *s
+	c.front = Clone(s.front)
+	c.back = Clone(s.back)
+	c.release = nil
+	return &c
+}
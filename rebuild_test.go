@@ -0,0 +1,175 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestDedup(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithAll(base, NotFound, io.EOF, NotFound, NotFound)
+
+	deduped := wrap.Dedup(err)
+	actual := deduped.Error()
+	if expected := "not found: EOF: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if n := strings.Count(actual, "not found"); n != 1 {
+		t.Fatalf("expected flag once but found it %d times", n)
+	}
+	if !errors.Is(deduped, NotFound) || !errors.Is(deduped, io.EOF) || !errors.Is(deduped, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	if orig := err.Error(); orig != "not found: not found: EOF: not found: some pig" {
+		t.Fatalf("expected original error to be unchanged but got %v", orig)
+	}
+
+	if err := wrap.Dedup(base); err != base {
+		t.Fatalf("expected original error but got %v", err)
+	}
+	err = wrap.With(base, NotFound)
+	if deduped := wrap.Dedup(err); deduped != err {
+		t.Fatalf("expected original error but got %v", deduped)
+	}
+}
+
+func TestWithout(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithAll(base, NotFound, io.EOF, NotFound)
+
+	without := wrap.Without(err, NotFound)
+	if errors.Is(without, NotFound) {
+		t.Fatal("unexpectedly found removed flag")
+	}
+	if !errors.Is(without, base) || !errors.Is(without, io.EOF) {
+		t.Fatal("failed to find remaining errors")
+	}
+	if actual, expected := without.Error(), "EOF: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	if actual := wrap.Without(wrap.With(base, NotFound), base); actual.Error() != "not found: some pig" {
+		t.Fatalf("expected innermost error to be kept but got %v", actual)
+	}
+	if actual := wrap.Without(base, base); actual != base {
+		t.Fatalf("expected original error but got %v", actual)
+	}
+}
+
+type upperError struct {
+	error
+}
+
+func (u upperError) Error() string {
+	return strings.ToUpper(u.error.Error())
+}
+
+func (u upperError) Unwrap() error {
+	return u.error
+}
+
+func TestMap(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithSep(wrap.With(base, NotFound), io.EOF, " | ")
+
+	upper := wrap.Map(err, func(e error) error {
+		return upperError{e}
+	})
+	if actual, expected := upper.Error(), "EOF | NOT FOUND: SOME PIG"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(upper, NotFound) || !errors.Is(upper, base) || !errors.Is(upper, io.EOF) {
+		t.Fatal("failed to find mapped errors")
+	}
+
+	dropped := wrap.Map(err, func(e error) error {
+		if e == NotFound {
+			return nil
+		}
+		return e
+	})
+	if actual, expected := dropped.Error(), "EOF | some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if errors.Is(dropped, NotFound) {
+		t.Fatal("unexpectedly found dropped error")
+	}
+
+	same := wrap.Map(err, func(e error) error { return e })
+	if same != err {
+		t.Fatal("expected unchanged error to be returned as is")
+	}
+	if wrap.Map(nil, func(e error) error { return e }) != nil {
+		t.Fatal("expected nil")
+	}
+}
+
+func TestReplaceRoot(t *testing.T) {
+	base := errors.New("pq: no rows")
+	err := wrap.WithAll(base, NotFound, io.EOF)
+
+	replaced := wrap.ReplaceRoot(err, ErrTxnFailed)
+	if actual, expected := replaced.Error(), "EOF: not found: transaction failed"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(replaced, NotFound) || !errors.Is(replaced, io.EOF) || !errors.Is(replaced, ErrTxnFailed) {
+		t.Fatal("failed to find errors after replacing root")
+	}
+	if errors.Is(replaced, base) {
+		t.Fatal("unexpectedly found replaced root")
+	}
+	if root := wrap.ReplaceRoot(err, nil); root != nil {
+		t.Fatalf("expected nil but got %v", root)
+	}
+	if root := wrap.ReplaceRoot(base, ErrTxnFailed); root != ErrTxnFailed {
+		t.Fatalf("expected new root but got %v", root)
+	}
+}
+
+func TestClone(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", wrap.With(base, io.EOF))
+	err := wrap.WithSep(wrap.With(wrapped, NotFound), wrap.With(io.ErrClosedPipe, isNotFound{}), " | ")
+
+	clone := wrap.Clone(err)
+	if clone == err {
+		t.Fatal("expected a new error")
+	}
+	if actual, expected := clone.Error(), err.Error(); actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	for _, target := range []error{base, io.EOF, NotFound, io.ErrClosedPipe, wrapped} {
+		if !errors.Is(clone, target) {
+			t.Errorf("failed to find %v in clone", target)
+		}
+	}
+	if !wrap.Equal(clone, err) {
+		t.Fatal("expected clone to equal the original")
+	}
+	// The errors in the stacks are shared.
+	flat, orig := wrap.Flatten(clone), wrap.Flatten(err)
+	for i := range orig {
+		if flat[i] != orig[i] {
+			t.Errorf("expected error %d to be shared but got %v", i, flat[i])
+		}
+	}
+	if wrap.Clone(base) != base {
+		t.Fatal("expected non-stack error unchanged")
+	}
+	if wrap.Clone(nil) != nil {
+		t.Fatal("expected nil")
+	}
+}
+
+func TestMapUncomparable(t *testing.T) {
+	err := wrap.With(errors.New("some pig"), sliceError{"a"})
+	mapped := wrap.Map(err, func(e error) error { return e })
+	if actual, expected := mapped.Error(), err.Error(); actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
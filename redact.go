@@ -0,0 +1,36 @@
+package wrap
+
+// RedactedMessage is the message of errors returned by Redact.
+const RedactedMessage = "[redacted]"
+
+// redacted is the error returned by Redact.
+type redacted struct {
+	err error
+}
+
+func (r *redacted) Error() string {
+	return RedactedMessage
+}
+
+func (r *redacted) Unwrap() error {
+	return r.err
+}
+
+// Redact returns an error that wraps err, but whose message is just
+// RedactedMessage, so that err's message is kept out of logs. The wrapped
+// error is still visible to errors.Is, errors.As and errors.Unwrap. If err is
+// nil, Redact returns nil.
+func Redact(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redacted{err: err}
+}
+
+// WithRedacted is like With, but back's message is replaced by
+// RedactedMessage, as in Redact. The returned error's message is therefore
+// front's message followed by the placeholder, while back can still be found
+// with errors.Is and errors.As.
+func WithRedacted(back, front error) error {
+	return With(Redact(back), front)
+}
@@ -0,0 +1,42 @@
+package wrap_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithRedacted(t *testing.T) {
+	secret := errors.New("dial postgres://admin:hunter2@db")
+	err := wrap.WithRedacted(secret, NotFound)
+
+	if actual, expected := err.Error(), "not found: [redacted]"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, secret) {
+		t.Fatal("failed to find redacted error")
+	}
+	if !errors.Is(err, NotFound) {
+		t.Fatal("failed to find flag")
+	}
+	if root := wrap.Root(err); root != secret {
+		t.Fatalf("expected to unwrap to %v but got %v", secret, root)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	secret := myError("token=abc123")
+	err := wrap.With(wrap.Redact(secret), NotFound)
+	if strings.Contains(err.Error(), "abc123") {
+		t.Fatalf("expected secret to be redacted from %q", err)
+	}
+	var my myError
+	if !errors.As(err, &my) || my != secret {
+		t.Fatal("failed to find redacted type")
+	}
+	if wrap.Redact(nil) != nil {
+		t.Fatal("expected nil")
+	}
+}
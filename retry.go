@@ -0,0 +1,60 @@
+package wrap
+
+// retryError is the type of ErrRetryable and ErrNonRetryable.
+type retryError struct {
+	msg       string
+	retryable bool
+}
+
+func (e *retryError) Error() string {
+	return e.msg
+}
+
+func (e *retryError) Retryable() bool {
+	return e.retryable
+}
+
+var (
+	// ErrRetryable marks an error as safe to retry. It has a Retryable
+	// method that returns true.
+	ErrRetryable error = &retryError{msg: "retryable", retryable: true}
+
+	// ErrNonRetryable marks an error as not safe to retry, even if it wraps
+	// a retryable error. It has a Retryable method that returns false.
+	ErrNonRetryable error = &retryError{msg: "not retryable", retryable: false}
+)
+
+// Retryable returns back wrapped by ErrRetryable, so its message is
+// "retryable: " followed by back's message. If back is nil, the returned error
+// is nil.
+func Retryable(back error) error {
+	return With(back, ErrRetryable)
+}
+
+// NonRetryable returns back wrapped by ErrNonRetryable, so its message is
+// "not retryable: " followed by back's message. If back is nil, the returned
+// error is nil.
+func NonRetryable(back error) error {
+	return With(back, ErrNonRetryable)
+}
+
+// IsRetryable reports whether err is safe to retry. The first error in err's
+// chain that either has a Retryable() bool method or matches ErrRetryable
+// through an Is(error) bool method decides, so the marker wrapped on last
+// wins, and NonRetryable overrides any retryable errors inside it. If no error
+// in the chain says either way, IsRetryable returns false.
+func IsRetryable(err error) bool {
+	var retryable bool
+	walk(err, func(err error) bool {
+		if r, ok := err.(interface{ Retryable() bool }); ok {
+			retryable = r.Retryable()
+			return false
+		}
+		if matches(err, ErrRetryable, true) {
+			retryable = true
+			return false
+		}
+		return true
+	})
+	return retryable
+}
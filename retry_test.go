@@ -0,0 +1,64 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestRetryable(t *testing.T) {
+	base := errors.New("some pig")
+	if wrap.IsRetryable(base) {
+		t.Fatal("expected unmarked error not to be retryable")
+	}
+
+	err := wrap.Retryable(base)
+	if actual, expected := err.Error(), "retryable: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !wrap.IsRetryable(wrap.With(err, NotFound)) {
+		t.Fatal("expected wrapped retryable error to be retryable")
+	}
+	if !errors.Is(err, wrap.ErrRetryable) {
+		t.Fatal("failed to find ErrRetryable")
+	}
+}
+
+func TestNonRetryable(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.NonRetryable(wrap.With(wrap.Retryable(base), NotFound))
+	if wrap.IsRetryable(err) {
+		t.Fatal("expected NonRetryable to override inner Retryable")
+	}
+	if !wrap.IsRetryable(wrap.Retryable(err)) {
+		t.Fatal("expected outer Retryable to override NonRetryable")
+	}
+}
+
+type retryableError struct{}
+
+func (retryableError) Error() string   { return "try again" }
+func (retryableError) Retryable() bool { return true }
+
+func TestIsRetryableMethod(t *testing.T) {
+	if !wrap.IsRetryable(wrap.With(retryableError{}, NotFound)) {
+		t.Fatal("expected error with Retryable method to be retryable")
+	}
+}
+
+type isRetryable struct{}
+
+func (isRetryable) Error() string        { return "is retryable" }
+func (isRetryable) Is(target error) bool { return target == wrap.ErrRetryable }
+
+func TestIsRetryableSentinel(t *testing.T) {
+	if !wrap.IsRetryable(wrap.With(errors.New("some pig"), isRetryable{})) {
+		t.Fatal("expected error matching ErrRetryable to be retryable")
+	}
+	err := fmt.Errorf("context: %w", wrap.NonRetryable(wrap.Retryable(errors.New("some pig"))))
+	if wrap.IsRetryable(err) {
+		t.Fatal("expected NonRetryable to override inner Retryable after wrapping")
+	}
+}
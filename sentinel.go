@@ -0,0 +1,103 @@
+package wrap
+
+// SentinelOption sets an attribute of the error returned by NewSentinel.
+type SentinelOption func(*sentinelAttrs)
+
+// sentinelAttrs holds the attributes set by SentinelOptions.
+type sentinelAttrs struct {
+	code      *int
+	status    *int
+	retryable *bool
+	severity  *Severity
+}
+
+// SentinelCode gives a sentinel a code, as reported by Code, like WithCode.
+// Codes from other systems, like gRPC status codes, can be recorded this way.
+func SentinelCode(code int) SentinelOption {
+	return func(a *sentinelAttrs) {
+		a.code = &code
+	}
+}
+
+// SentinelStatus gives a sentinel an HTTP status code, as reported by
+// HTTPStatus, like WithStatus.
+func SentinelStatus(status int) SentinelOption {
+	return func(a *sentinelAttrs) {
+		a.status = &status
+	}
+}
+
+// SentinelRetryable makes a sentinel retryable, as reported by IsRetryable,
+// like Retryable. If retryable is false, the sentinel overrides any retryable
+// errors it is wrapped over, like NonRetryable.
+func SentinelRetryable(retryable bool) SentinelOption {
+	return func(a *sentinelAttrs) {
+		a.retryable = &retryable
+	}
+}
+
+// SentinelSeverity gives a sentinel a severity, as reported by SeverityOf,
+// like WithSeverity.
+func SentinelSeverity(s Severity) SentinelOption {
+	return func(a *sentinelAttrs) {
+		a.severity = &s
+	}
+}
+
+// sentinel is the error created by NewSentinel.
+type sentinel struct {
+	msg   string
+	attrs []error
+}
+
+func (e *sentinel) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the errors carrying the sentinel's attributes, which is how
+// the extractors in this package find them.
+func (e *sentinel) Unwrap() []error {
+	return e.attrs
+}
+
+// NewSentinel returns a new error with the message msg and the attributes set
+// by opts, which carries them wherever it goes. Like an error from errors.New,
+// each call returns a distinct error, meant to be stored in a package variable
+// and matched with errors.Is:
+//
+//	var ErrUnavailable = wrap.NewSentinel("service unavailable",
+//		wrap.SentinelStatus(http.StatusServiceUnavailable),
+//		wrap.SentinelRetryable(true),
+//	)
+//
+// When the sentinel is wrapped over another error, as in With(err,
+// ErrUnavailable), Code, HTTPStatus, IsRetryable and SeverityOf find its
+// attributes, just as they would for an error built with WithCode, WithStatus,
+// Retryable or WithSeverity. Its message is only msg.
+//
+// The attributes are the errors the sentinel wraps, so they also show up in
+// Chain and Dump, and errors.Is(ErrUnavailable, ErrRetryable) is true.
+func NewSentinel(msg string, opts ...SentinelOption) error {
+	var a sentinelAttrs
+	for _, opt := range opts {
+		opt(&a)
+	}
+	var attrs []error
+	if a.code != nil {
+		attrs = append(attrs, &CodedError{code: *a.code})
+	}
+	if a.status != nil {
+		attrs = append(attrs, &statusError{status: *a.status})
+	}
+	if a.retryable != nil {
+		if *a.retryable {
+			attrs = append(attrs, ErrRetryable)
+		} else {
+			attrs = append(attrs, ErrNonRetryable)
+		}
+	}
+	if a.severity != nil {
+		attrs = append(attrs, &severityError{severity: *a.severity})
+	}
+	return &sentinel{msg: msg, attrs: attrs}
+}
@@ -0,0 +1,63 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+var ErrUnavailable = wrap.NewSentinel("service unavailable",
+	wrap.SentinelStatus(http.StatusServiceUnavailable),
+	wrap.SentinelCode(14),
+	wrap.SentinelRetryable(true),
+	wrap.SentinelSeverity(wrap.SeverityWarn),
+)
+
+func TestNewSentinel(t *testing.T) {
+	base := errors.New("some pig")
+	err := fmt.Errorf("context: %w", wrap.With(base, ErrUnavailable))
+
+	if actual, expected := err.Error(), "context: service unavailable: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, ErrUnavailable) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	if status, ok := wrap.HTTPStatus(err); !ok || status != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503 but got %v", status)
+	}
+	if code, ok := wrap.Code(err); !ok || code != 14 {
+		t.Fatalf("expected code 14 but got %v", code)
+	}
+	if !wrap.IsRetryable(err) {
+		t.Fatal("expected error to be retryable")
+	}
+	if sev, ok := wrap.SeverityOf(err); !ok || sev != wrap.SeverityWarn {
+		t.Fatalf("expected WARN but got %v", sev)
+	}
+	if errors.Is(err, wrap.NewSentinel("service unavailable")) {
+		t.Fatal("expected sentinels with the same message to be distinct")
+	}
+}
+
+func TestNewSentinelNonRetryable(t *testing.T) {
+	errBadInput := wrap.NewSentinel("bad input", wrap.SentinelRetryable(false))
+	err := wrap.With(wrap.Retryable(errors.New("some pig")), errBadInput)
+	if wrap.IsRetryable(err) {
+		t.Fatal("expected sentinel to override retryable error")
+	}
+	if _, ok := wrap.HTTPStatus(err); ok {
+		t.Fatal("expected no status")
+	}
+
+	plain := wrap.NewSentinel("plain")
+	if _, ok := wrap.Code(plain); ok || wrap.IsRetryable(plain) {
+		t.Fatal("expected sentinel without options to have no attributes")
+	}
+	if actual, expected := wrap.With(errors.New("some pig"), plain).Error(), "plain: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
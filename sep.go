@@ -0,0 +1,46 @@
+package wrap
+
+// DefaultSeparator is written between the messages of the front and back
+// errors of errors returned by With and the other functions in this package
+// that don't take a separator. Changing it only affects errors created
+// afterward, and since it isn't safe to change concurrently with creating
+// errors, it should only be set during program initialization.
+var DefaultSeparator = ": "
+
+// WithSep is like With, but the returned error's message separates the front
+// and back messages with sep instead of DefaultSeparator. The separator only
+// applies to this error, so errors wrapped over or under it keep their own.
+func WithSep(back, front error, sep string) error {
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	s := makeStack(front, back, comparable)
+	s.sep = sep
+	return &s
+}
+
+// WithReversed is like With, but the returned error's message puts back's
+// message first, followed by DefaultSeparator and front's message, for those
+// who prefer to read the root cause first. Only the message is reversed: Is,
+// As and Unwrap still visit front before back, exactly as for With.
+func WithReversed(back, front error) error {
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	s := makeStack(front, back, comparable)
+	s.reversed = true
+	return &s
+}
+
+// Separator returns the separator written between the front and back messages
+// of err, if err was returned by With or another function in this package that
+// wraps one error over another, and true. Only err itself is checked, not the
+// errors it wraps, so it returns "" and false for any other error.
+func Separator(err error) (string, bool) {
+	if s, ok := err.(*stack); ok {
+		return s.sep, true
+	}
+	return "", false
+}
@@ -0,0 +1,106 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithSep(t *testing.T) {
+	one := errors.New("one")
+	two := errors.New("two")
+	three := errors.New("three")
+	four := errors.New("four")
+
+	err := wrap.WithSep(two, one, " -> ")
+	if actual, expected := err.Error(), "one -> two"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, one) || !errors.Is(err, two) {
+		t.Fatal("failed to find wrapped errors")
+	}
+
+	err = wrap.With(wrap.WithSep(wrap.With(four, three), err, " | "), errors.New("zero"))
+	if actual, expected := err.Error(), "zero: one -> two | three: four"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	// Unwrapping into front should keep the separator.
+	err = wrap.WithSep(four, fmt.Errorf("three: %w", one), " | ")
+	if actual, expected := errors.Unwrap(err).Error(), "one | four"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestDefaultSeparator(t *testing.T) {
+	defer func(sep string) { wrap.DefaultSeparator = sep }(wrap.DefaultSeparator)
+
+	before := wrap.With(errors.New("two"), errors.New("one"))
+	wrap.DefaultSeparator = " / "
+	after := wrap.With(errors.New("two"), errors.New("one"))
+
+	if actual, expected := before.Error(), "one: two"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if actual, expected := after.Error(), "one / two"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestWithReversed(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithReversed(base, NotFound)
+	if actual, expected := err.Error(), "some pig: not found"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	// Traversal is the same as With, front first.
+	chain := wrap.Chain(err)
+	if len(chain) != 2 || chain[0] != NotFound || chain[1] != base {
+		t.Fatalf("expected front then back but got %v", chain)
+	}
+	if !errors.Is(err, NotFound) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	if unwrapped := errors.Unwrap(err); unwrapped != base {
+		t.Fatalf("expected back error but got %v", unwrapped)
+	}
+
+	// Only the reversed stack's own pair is swapped.
+	err = wrap.With(wrap.WithReversed(wrap.With(base, errors.New("wilbur")), io.EOF), errors.New("charlotte"))
+	if actual, expected := err.Error(), "charlotte: wilbur: some pig: EOF"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	// Unwrapping into front keeps the order.
+	err = wrap.WithReversed(base, fmt.Errorf("wilbur: %w", NotFound))
+	if actual, expected := errors.Unwrap(err).Error(), "some pig: not found"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if err := wrap.WithReversed(nil, NotFound); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	if err := wrap.WithReversed(base, nil); err != base {
+		t.Fatalf("expected back error but got %v", err)
+	}
+}
+
+func TestSeparator(t *testing.T) {
+	base := errors.New("some pig")
+	if sep, ok := wrap.Separator(wrap.With(base, NotFound)); !ok || sep != ": " {
+		t.Fatalf("expected default separator but got %q", sep)
+	}
+	err := wrap.WithSep(wrap.With(base, NotFound), io.EOF, " | ")
+	if sep, ok := wrap.Separator(err); !ok || sep != " | " {
+		t.Fatalf("expected custom separator but got %q", sep)
+	}
+	if sep, ok := wrap.Separator(fmt.Errorf("context: %w", err)); ok || sep != "" {
+		t.Fatalf("expected no separator but got %q", sep)
+	}
+	if sep, ok := wrap.Separator(nil); ok || sep != "" {
+		t.Fatalf("expected no separator but got %q", sep)
+	}
+}
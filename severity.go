@@ -0,0 +1,72 @@
+package wrap
+
+import "strconv"
+
+// Severity is how serious an error is.
+type Severity int
+
+// The severities, from least to most serious.
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+	SeverityFatal
+)
+
+var severityNames = [...]string{
+	SeverityDebug: "DEBUG",
+	SeverityInfo:  "INFO",
+	SeverityWarn:  "WARN",
+	SeverityError: "ERROR",
+	SeverityFatal: "FATAL",
+}
+
+// String returns the severity's name in capitals, like "WARN".
+func (s Severity) String() string {
+	if s >= 0 && int(s) < len(severityNames) {
+		return severityNames[s]
+	}
+	return "Severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+// severityError is the error created by WithSeverity.
+type severityError struct {
+	severity Severity
+}
+
+func (e *severityError) Error() string {
+	return "[" + e.severity.String() + "]"
+}
+
+func (e *severityError) Severity() Severity {
+	return e.severity
+}
+
+// WithSeverity returns an error that represents an error carrying the given
+// severity wrapped over back, so its message is like "[WARN]: " followed by
+// back's message. If back is nil, the returned error is nil.
+func WithSeverity(back error, s Severity) error {
+	return With(back, &severityError{severity: s})
+}
+
+// SeverityOf returns the highest severity of the errors in err's chain with a
+// Severity() Severity method, such as those added by WithSeverity, and true.
+// Unlike most of the other extractors in this package, the outermost error
+// doesn't win, so wrapping an error can't make it look less serious. If no
+// error in the chain has a severity, SeverityOf returns SeverityDebug and
+// false.
+func SeverityOf(err error) (Severity, bool) {
+	var max Severity
+	var found bool
+	walk(err, func(err error) bool {
+		if s, ok := err.(interface{ Severity() Severity }); ok {
+			if sev := s.Severity(); !found || sev > max {
+				max = sev
+			}
+			found = true
+		}
+		return true
+	})
+	return max, found
+}
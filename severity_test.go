@@ -0,0 +1,41 @@
+package wrap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithSeverity(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithSeverity(base, wrap.SeverityWarn)
+	if actual, expected := err.Error(), "[WARN]: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if sev, ok := wrap.SeverityOf(err); !ok || sev != wrap.SeverityWarn {
+		t.Fatalf("expected %v but got %v", wrap.SeverityWarn, sev)
+	}
+	if sev, ok := wrap.SeverityOf(base); ok || sev != wrap.SeverityDebug {
+		t.Fatalf("expected no severity but got %v", sev)
+	}
+}
+
+func TestSeverityOfMax(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithSeverity(base, wrap.SeverityInfo)
+	err = wrap.WithSeverity(wrap.With(err, NotFound), wrap.SeverityFatal)
+	err = wrap.WithSeverity(err, wrap.SeverityWarn)
+	if sev, _ := wrap.SeverityOf(err); sev != wrap.SeverityFatal {
+		t.Fatalf("expected highest severity %v but got %v", wrap.SeverityFatal, sev)
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	if s := wrap.SeverityError.String(); s != "ERROR" {
+		t.Fatalf("expected ERROR but got %v", s)
+	}
+	if s := wrap.Severity(9).String(); s != "Severity(9)" {
+		t.Fatalf("expected Severity(9) but got %v", s)
+	}
+}
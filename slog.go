@@ -0,0 +1,54 @@
+//go:build go1.21
+
+package wrap
+
+import (
+	"log/slog"
+	"sort"
+)
+
+// LogValue implements slog.LogValuer. The error is logged as a group with the
+// full message under "msg" and the message of each wrapped error under
+// "layers", so that log queries can match on any one of them.
+func (s *stack) LogValue() slog.Value {
+	leaves := flatten(s, nil)
+	msgs := make([]string, len(leaves))
+	for i, err := range leaves {
+		msgs[i] = err.Error()
+	}
+	return slog.GroupValue(
+		slog.String("msg", s.Error()),
+		slog.Any("layers", msgs),
+	)
+}
+
+// Attrs returns slog attributes describing err, for use with methods like
+// slog.Logger.LogAttrs. They are the message under "msg", followed by any of
+// the tags from Tags under "tags", the code from Code under "code", and the
+// fields from Fields as a group under "fields", sorted by key. Attributes for
+// annotations err doesn't have are left out. If err is nil, Attrs returns nil.
+func Attrs(err error) []slog.Attr {
+	if err == nil {
+		return nil
+	}
+	attrs := []slog.Attr{slog.String("msg", err.Error())}
+	if tags := Tags(err); tags != nil {
+		attrs = append(attrs, slog.Any("tags", tags))
+	}
+	if code, ok := Code(err); ok {
+		attrs = append(attrs, slog.Int("code", code))
+	}
+	if fields := Fields(err); fields != nil {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		group := make([]any, len(keys))
+		for i, k := range keys {
+			group[i] = slog.Any(k, fields[k])
+		}
+		attrs = append(attrs, slog.Group("fields", group...))
+	}
+	return attrs
+}
@@ -0,0 +1,69 @@
+//go:build go1.21
+
+package wrap_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestLogValue(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.With(wrap.With(fmt.Errorf("wilbur: %w", base), NotFound), errors.New("oops"))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Error("failed", "err", err)
+
+	actual := buf.String()
+	expected := `{"level":"ERROR","msg":"failed","err":{"msg":"oops: not found: wilbur: some pig","layers":["oops","not found","wilbur: some pig"]}}` + "\n"
+	if actual != expected {
+		t.Fatalf("expected %s but got %s", expected, actual)
+	}
+	if err.Error() != "oops: not found: wilbur: some pig" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	err := wrap.WithTag(wrap.WithCode(errors.New("some pig"), 404), "barn", "farm")
+	err = wrap.Annotate(err, "user", 5)
+
+	attrs := wrap.Attrs(err)
+	var actual []string
+	for _, a := range attrs {
+		actual = append(actual, a.String())
+	}
+	expected := []string{
+		"msg=user=5: code 404: some pig",
+		"tags=[barn farm]",
+		"code=404",
+		"fields=[user=5]",
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if err.Error() != "user=5: code 404: some pig" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+
+	attrs = wrap.Attrs(errors.New("some pig"))
+	if len(attrs) != 1 || attrs[0].Key != "msg" || attrs[0].Value.String() != "some pig" {
+		t.Fatalf("expected only the message but got %v", attrs)
+	}
+	if attrs := wrap.Attrs(nil); attrs != nil {
+		t.Fatalf("expected nil but got %v", attrs)
+	}
+}
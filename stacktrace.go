@@ -0,0 +1,131 @@
+package wrap
+
+import (
+	// See the note on this import in with.go.
+	reflectlite "reflect"
+	"runtime"
+)
+
+// maxTraceDepth is the most frames WithStack will record.
+const maxTraceDepth = 32
+
+// WithStack is like With, but it also records the stack of the function that
+// called it. The returned error has a StackTrace method returning the
+// recorded program counters and a Frames method to resolve them.
+func WithStack(back, front error) error {
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	s := makeStack(front, back, comparable)
+	s.trace = callers(3)
+	return &s
+}
+
+// WithStackSkip is like WithStack, but skips the given number of frames above
+// the function that called it, so that helpers wrapping errors for their
+// callers can record the stack from their caller's call site. A skip of 0 is
+// the same as WithStack, and a negative skip is treated as 0.
+func WithStackSkip(back, front error, skip int) error {
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	if skip < 0 {
+		skip = 0
+	}
+	s := makeStack(front, back, comparable)
+	s.trace = callers(3 + skip)
+	return &s
+}
+
+// callers returns the program counters of the calling goroutine's stack,
+// skipping the given number of frames as in runtime.Callers.
+func callers(skip int) []uintptr {
+	var pcs [maxTraceDepth]uintptr
+	n := runtime.Callers(skip, pcs[:])
+	trace := make([]uintptr, n)
+	copy(trace, pcs[:n])
+	return trace
+}
+
+// StackTrace returns the program counters recorded by WithStack, starting with
+// the function that called it. It returns nil if no stack was recorded.
+func (s *stack) StackTrace() []uintptr {
+	return s.trace
+}
+
+// Frames returns the frames of the stack recorded by WithStack. If no stack
+// was recorded, the frames are empty.
+func (s *stack) Frames() *runtime.Frames {
+	return runtime.CallersFrames(s.trace)
+}
+
+// StackTrace returns the deepest stack trace recorded in err's tree, and true.
+// Traces are found in errors from WithStack and the like, and in any other
+// error with a StackTrace method returning a slice of program counters, such
+// as the errors from github.com/pkg/errors, whose StackTrace method returns a
+// slice of uintptr-based frames. Those are found without importing
+// pkg/errors, by looking the method up with reflect's MethodByName, and are
+// returned as plain program counters, which can be passed to
+// runtime.CallersFrames. Before Go 1.22, any use of MethodByName, even with a
+// constant name as here, makes the linker keep every exported method of every
+// reachable type, so on those toolchains every program importing this package
+// is built without that dead code elimination. The deepest trace is the last
+// one found in the order errors.Is visits errors, which is usually recorded closest to where
+// the problem started. If there is no trace, StackTrace returns nil and false.
+func StackTrace(err error) ([]uintptr, bool) {
+	trace := deepestTrace(err, nil)
+	return trace, trace != nil
+}
+
+// deepestTrace returns the last non-empty trace in err's tree, or found if
+// there is none.
+func deepestTrace(err error, found []uintptr) []uintptr {
+	for err != nil {
+		if trace := traceOf(err); len(trace) > 0 {
+			found = trace
+		}
+		switch x := err.(type) {
+		case *stack:
+			found = deepestTrace(x.front, found)
+			err = x.back
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, err := range x.Unwrap() {
+				found = deepestTrace(err, found)
+			}
+			return found
+		default:
+			return found
+		}
+	}
+	return found
+}
+
+// traceOf returns the program counters returned by err's StackTrace method,
+// if it has one that returns a slice of uintptr or a slice of a type based on
+// uintptr, and nil otherwise.
+func traceOf(err error) []uintptr {
+	if t, ok := err.(interface{ StackTrace() []uintptr }); ok {
+		return t.StackTrace()
+	}
+	m := reflectlite.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() {
+		return nil
+	}
+	typ := m.Type()
+	if typ.NumIn() != 0 || typ.NumOut() != 1 {
+		return nil
+	}
+	if out := typ.Out(0); out.Kind() != reflectlite.Slice || out.Elem().Kind() != reflectlite.Uintptr {
+		return nil
+	}
+	frames := m.Call(nil)[0]
+	trace := make([]uintptr, frames.Len())
+	for i := range trace {
+		trace[i] = uintptr(frames.Index(i).Uint())
+	}
+	return trace
+}
@@ -0,0 +1,140 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+type tracer interface {
+	StackTrace() []uintptr
+	Frames() *runtime.Frames
+}
+
+func TestWithStack(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithStack(base, NotFound)
+
+	if actual, expected := err.Error(), "not found: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, NotFound) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+
+	tr, ok := err.(tracer)
+	if !ok {
+		t.Fatal("expected error to have a stack trace")
+	}
+	if len(tr.StackTrace()) == 0 {
+		t.Fatal("expected a non-empty stack trace")
+	}
+	frame, _ := tr.Frames().Next()
+	if !strings.HasSuffix(frame.Function, ".TestWithStack") {
+		t.Fatalf("expected first frame in TestWithStack but got %v", frame.Function)
+	}
+
+	if err := wrap.WithStack(nil, NotFound); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	if err := wrap.WithStack(base, nil); err != base {
+		t.Fatalf("expected original error but got %v", err)
+	}
+	tr = wrap.With(base, io.EOF).(tracer)
+	if tr.StackTrace() != nil {
+		t.Fatal("expected no stack trace from With")
+	}
+}
+
+// notFound is a helper that records the stack of its caller.
+func notFound(err error) error {
+	return wrap.WithStackSkip(err, NotFound, 1)
+}
+
+func TestWithStackSkip(t *testing.T) {
+	base := errors.New("some pig")
+	err := notFound(base)
+	if actual, expected := err.Error(), "not found: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	frame, _ := err.(tracer).Frames().Next()
+	if !strings.HasSuffix(frame.Function, ".TestWithStackSkip") {
+		t.Fatalf("expected first frame in TestWithStackSkip but got %v", frame.Function)
+	}
+
+	frame, _ = wrap.WithStackSkip(base, NotFound, -1).(tracer).Frames().Next()
+	if !strings.HasSuffix(frame.Function, ".TestWithStackSkip") {
+		t.Fatalf("expected negative skip to be ignored but got %v", frame.Function)
+	}
+	if err := wrap.WithStackSkip(nil, NotFound, 1); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+// frame and frames mimic the types from github.com/pkg/errors.
+type frame uintptr
+
+type frames []frame
+
+// pkgError is like the errors from github.com/pkg/errors, whose StackTrace
+// methods return their own frame type.
+type pkgError struct {
+	msg   string
+	trace frames
+}
+
+func (e *pkgError) Error() string {
+	return e.msg
+}
+
+func (e *pkgError) StackTrace() frames {
+	return e.trace
+}
+
+func newPkgError(msg string) *pkgError {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+	trace := make(frames, len(pcs))
+	for i, pc := range pcs {
+		trace[i] = frame(pc)
+	}
+	return &pkgError{msg: msg, trace: trace}
+}
+
+func TestStackTraceFunc(t *testing.T) {
+	inner := newPkgError("some pig")
+	err := wrap.WithStack(fmt.Errorf("wilbur: %w", inner), NotFound)
+	err = wrap.With(err, io.EOF)
+
+	trace, ok := wrap.StackTrace(err)
+	if !ok || len(trace) != len(inner.trace) {
+		t.Fatalf("expected the deepest trace but got %v", trace)
+	}
+	for i, pc := range trace {
+		if pc != uintptr(inner.trace[i]) {
+			t.Fatalf("expected frame %d to be %v but got %v", i, inner.trace[i], pc)
+		}
+	}
+	fr, _ := runtime.CallersFrames(trace).Next()
+	if !strings.HasSuffix(fr.Function, ".TestStackTraceFunc") {
+		t.Fatalf("expected first frame in TestStackTraceFunc but got %v", fr.Function)
+	}
+
+	// Without the pkg/errors style error, the trace from WithStack is used.
+	err = wrap.With(wrap.WithStack(errors.New("some pig"), NotFound), io.EOF)
+	trace, ok = wrap.StackTrace(err)
+	if !ok || len(trace) == 0 {
+		t.Fatal("expected trace from WithStack")
+	}
+	if trace, ok := wrap.StackTrace(wrap.With(errors.New("some pig"), io.EOF)); ok || trace != nil {
+		t.Fatalf("expected no trace but got %v", trace)
+	}
+	if trace, ok := wrap.StackTrace(nil); ok || trace != nil {
+		t.Fatalf("expected no trace but got %v", trace)
+	}
+}
@@ -0,0 +1,66 @@
+package wrap
+
+// tagsError is the error created by WithTag. Its message is empty, so it
+// doesn't change the message of the error it is wrapped over.
+type tagsError struct {
+	tags []string
+}
+
+func (e *tagsError) Error() string {
+	return ""
+}
+
+func (e *tagsError) Tags() []string {
+	return e.tags
+}
+
+// WithTag returns an error that represents an error carrying the given tags
+// wrapped over back, which can be retrieved with Tags and HasTag. The returned
+// error's message is the same as back's. If there are no tags, back is
+// returned unchanged, and if back is nil, the returned error is nil.
+func WithTag(back error, tags ...string) error {
+	if len(tags) == 0 {
+		return back
+	}
+	return With(back, &tagsError{tags: append([]string(nil), tags...)})
+}
+
+// Tags returns the tags of every error in err's chain with a Tags() []string
+// method, such as those added by WithTag, outermost first and with duplicates
+// removed. If no error in the chain has tags, it returns nil.
+func Tags(err error) []string {
+	var tags []string
+	seen := map[string]bool{}
+	walk(err, func(err error) bool {
+		t, ok := err.(interface{ Tags() []string })
+		if !ok {
+			return true
+		}
+		for _, tag := range t.Tags() {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+		return true
+	})
+	return tags
+}
+
+// HasTag reports whether any error in err's chain has the given tag, as
+// returned by Tags.
+func HasTag(err error, tag string) bool {
+	var found bool
+	walk(err, func(err error) bool {
+		if t, ok := err.(interface{ Tags() []string }); ok {
+			for _, tg := range t.Tags() {
+				if tg == tag {
+					found = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
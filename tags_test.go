@@ -0,0 +1,34 @@
+package wrap_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithTag(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithTag(base, "db", "transient")
+	err = wrap.WithTag(wrap.With(err, NotFound), "api", "db")
+
+	if actual, expected := err.Error(), "not found: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if actual, expected := wrap.Tags(err), []string{"api", "db", "transient"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !wrap.HasTag(err, "transient") {
+		t.Fatal("failed to find inner tag")
+	}
+	if wrap.HasTag(err, "fatal") {
+		t.Fatal("unexpectedly found tag")
+	}
+	if tags := wrap.Tags(base); tags != nil {
+		t.Fatalf("expected no tags but got %v", tags)
+	}
+	if err := wrap.WithTag(base); err != base {
+		t.Fatalf("expected original error but got %v", err)
+	}
+}
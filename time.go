@@ -0,0 +1,51 @@
+package wrap
+
+import (
+	"errors"
+	"time"
+)
+
+// timeError is the error created by WithTime. Its message is empty, so it
+// doesn't change the message of the error it is wrapped over. Timestamps are
+// for post-mortem tooling rather than people reading the message, and putting
+// one in the message would make otherwise identical errors from different
+// requests look different in logs.
+type timeError struct {
+	t time.Time
+}
+
+func (e *timeError) Error() string {
+	return ""
+}
+
+func (e *timeError) Time() time.Time {
+	return e.t
+}
+
+// WithTime returns an error that represents an error carrying t wrapped over
+// back, which can be retrieved with TimeOf. The returned error's message is the
+// same as back's. If back is nil, the returned error is nil.
+func WithTime(back error, t time.Time) error {
+	return With(back, &timeError{t: t})
+}
+
+// WithNow is like WithTime, recording the current time.
+func WithNow(back error) error {
+	if back == nil {
+		return nil
+	}
+	return WithTime(back, time.Now())
+}
+
+// TimeOf returns the time of the first error in err's chain with a Time()
+// time.Time method, such as those added by WithTime, and true. Since the chain
+// is searched from the outside in, the time from the last call to WithTime
+// wins. If no error in the chain has a time, TimeOf returns the zero time and
+// false.
+func TimeOf(err error) (time.Time, bool) {
+	var t interface{ Time() time.Time }
+	if errors.As(err, &t) {
+		return t.Time(), true
+	}
+	return time.Time{}, false
+}
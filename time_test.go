@@ -0,0 +1,49 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestWithTime(t *testing.T) {
+	base := errors.New("some pig")
+	inner := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := wrap.WithTime(base, inner)
+	if actual, expected := err.Error(), "some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if tm, ok := wrap.TimeOf(err); !ok || !tm.Equal(inner) {
+		t.Fatalf("expected %v but got %v", inner, tm)
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+
+	outer := inner.Add(time.Hour)
+	err = wrap.WithTime(fmt.Errorf("context: %w", wrap.With(err, NotFound)), outer)
+	if tm, ok := wrap.TimeOf(err); !ok || !tm.Equal(outer) {
+		t.Fatalf("expected outermost time %v but got %v", outer, tm)
+	}
+	if tm, ok := wrap.TimeOf(base); ok || !tm.IsZero() {
+		t.Fatalf("expected no time but got %v", tm)
+	}
+	if err := wrap.WithTime(nil, inner); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestWithNow(t *testing.T) {
+	before := time.Now()
+	err := wrap.WithNow(errors.New("some pig"))
+	after := time.Now()
+	if tm, ok := wrap.TimeOf(err); !ok || tm.Before(before) || tm.After(after) {
+		t.Fatalf("expected time between %v and %v but got %v", before, after, tm)
+	}
+	if err := wrap.WithNow(nil); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package wrap
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TruncatedError returns err's message, as returned by Error, cut short after
+// the messages of the first maxLayers errors that make it up, as returned by
+// Flatten. The rest are replaced by a note like "... (3 more)", written after
+// the separator that would have come next, so that very deep chains can't
+// flood logs. Errors with empty messages aren't counted. If maxLayers is zero
+// or less, or err doesn't have more layers than that, the whole message is
+// returned, and if err is nil, TruncatedError returns "".
+func TruncatedError(err error, maxLayers int) string {
+	if err == nil {
+		return ""
+	}
+	if maxLayers <= 0 {
+		return err.Error()
+	}
+	t := truncation{max: maxLayers}
+	var b strings.Builder
+	t.write(&b, err, "")
+	if t.skipped > 0 {
+		b.WriteString(t.lead)
+		b.WriteString("... (")
+		b.WriteString(strconv.Itoa(t.skipped))
+		b.WriteString(" more)")
+	}
+	return b.String()
+}
+
+// truncation tracks the layers written by TruncatedError.
+type truncation struct {
+	max, written, skipped int
+
+	// lead is the separator that would have been written before the first
+	// skipped message.
+	lead string
+}
+
+// write is like writeMessage, but once t.max messages have been written, it
+// only counts the rest.
+func (t *truncation) write(b *strings.Builder, err error, lead string) {
+	if s, ok := err.(*stack); ok {
+		first, second := s.front, s.back
+		if s.reversed {
+			first, second = second, first
+		}
+		n := t.written
+		t.write(b, first, lead)
+		if t.written > n {
+			lead = s.sep
+		}
+		t.write(b, second, lead)
+		return
+	}
+	msg := err.Error()
+	if msg == "" {
+		return
+	}
+	if t.written == t.max {
+		if t.skipped == 0 {
+			t.lead = lead
+		}
+		t.skipped++
+		return
+	}
+	if b.Len() > 0 {
+		b.WriteString(lead)
+	}
+	b.WriteString(msg)
+	t.written++
+}
@@ -0,0 +1,38 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+func TestTruncatedError(t *testing.T) {
+	err := wrap.WithTag(fmt.Errorf("wilbur: %w", errors.New("some pig")), "barn")
+	err = wrap.WithAll(err, NotFound, errors.New("three"))
+	err = wrap.WithSep(err, errors.New("four"), " | ")
+
+	tests := []struct {
+		max      int
+		expected string
+	}{
+		{0, "four | three: not found: wilbur: some pig"},
+		{1, "four | ... (3 more)"},
+		{2, "four | three: ... (2 more)"},
+		{3, "four | three: not found: ... (1 more)"},
+		{4, "four | three: not found: wilbur: some pig"},
+		{10, "four | three: not found: wilbur: some pig"},
+	}
+	for _, test := range tests {
+		if actual := wrap.TruncatedError(err, test.max); actual != test.expected {
+			t.Errorf("%d: expected %q but got %q", test.max, test.expected, actual)
+		}
+	}
+	if actual, expected := wrap.TruncatedError(errors.New("some pig"), 1), "some pig"; actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+	if actual := wrap.TruncatedError(nil, 1); actual != "" {
+		t.Fatalf("expected empty message but got %q", actual)
+	}
+}
@@ -0,0 +1,45 @@
+package wrap
+
+import "reflect"
+
+// valueError is the error created by WithValue. Its message is empty, so it
+// doesn't change the message of the error it is wrapped over.
+type valueError struct {
+	key, val interface{}
+}
+
+func (e *valueError) Error() string {
+	return ""
+}
+
+// WithValue returns an error that represents an error carrying val under key
+// wrapped over back, which can be retrieved with Value. The returned error's
+// message is the same as back's. If back is nil, the returned error is nil.
+//
+// As with context.WithValue, key must be comparable, and should be of an
+// unexported type defined by the package using it, so that keys from
+// different packages can't collide.
+func WithValue(back error, key, val interface{}) error {
+	if key == nil {
+		panic("wrap: nil key")
+	}
+	if !reflect.TypeOf(key).Comparable() {
+		panic("wrap: key is not comparable")
+	}
+	return With(back, &valueError{key: key, val: val})
+}
+
+// Value returns the value stored under key by the outermost call to WithValue
+// in err's chain, so values wrapped on later hide those under them. If no
+// value is stored under key, Value returns nil.
+func Value(err error, key interface{}) interface{} {
+	var val interface{}
+	walk(err, func(err error) bool {
+		if v, ok := err.(*valueError); ok && v.key == key {
+			val = v.val
+			return false
+		}
+		return true
+	})
+	return val
+}
@@ -0,0 +1,47 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+type valueKey string
+
+func TestWithValue(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithValue(base, valueKey("user"), 5)
+	err = wrap.WithValue(fmt.Errorf("context: %w", err), valueKey("request"), "abc")
+	err = wrap.WithValue(wrap.With(err, NotFound), valueKey("user"), 7)
+
+	if actual, expected := err.Error(), "not found: context: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if v := wrap.Value(err, valueKey("user")); v != 7 {
+		t.Fatalf("expected outermost value 7 but got %v", v)
+	}
+	if v := wrap.Value(err, valueKey("request")); v != "abc" {
+		t.Fatalf("expected inner value abc but got %v", v)
+	}
+	if v := wrap.Value(err, "user"); v != nil {
+		t.Fatalf("expected keys of different types not to match but got %v", v)
+	}
+	if v := wrap.Value(base, valueKey("user")); v != nil {
+		t.Fatalf("expected no value but got %v", v)
+	}
+}
+
+func TestWithValuePanics(t *testing.T) {
+	for _, key := range []interface{}{nil, []string{"user"}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for key %v", key)
+				}
+			}()
+			wrap.WithValue(errors.New("some pig"), key, 5)
+		}()
+	}
+}
@@ -2,6 +2,9 @@ package wrap
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	// reflectlite is a package internal to the stdlib, but its API is the same
 	// as reflect. This renaming keeps the code below identical to that in the
 	// internals of the errors package.
@@ -15,28 +18,227 @@ import (
 // error first, until it runs out of wrapped errors, and then return the back
 // error. This is also the order that Is and As will read the wrapped errors.
 //
-// The returned error's message will be the concatenation of the two error strings.
+// The returned error's message will be the concatenation of the two error
+// strings, separated by DefaultSeparator. If either message is empty, the
+// separator is left out and the message is just the other one, so there is
+// never a dangling separator.
+//
+// If front and back are the same error, back is returned unchanged, rather
+// than an error with the same message twice. The same goes for the other
+// functions in this package that wrap a given front error over back, like
+// WithSep and WithStack. See also DedupMatching, which only applies to With.
 func With(back, front error) error {
+	if DedupMatching && back != nil && front != nil && errors.Is(front, back) {
+		return back
+	}
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	s := makeStack(front, back, comparable)
+	return &s
+}
+
+// checkWrap makes the checks shared by the functions that wrap front over
+// back. If they mean that nothing should be wrapped, it returns the error to
+// return instead and false: nil if back is nil, back if front is nil or the
+// same error as back, or the error from limitDepth if MaxDepth was reached.
+// Otherwise, it calls OnWith, and returns true and whether front is comparable,
+// so the new stack doesn't need to work it out again.
+func checkWrap(back, front error) (err error, comparable, ok bool) {
 	if back == nil {
-		return nil
+		return nil, false, false
 	}
 	if front == nil {
+		return back, false, false
+	}
+	// Comparing interfaces can only panic if both hold the same uncomparable
+	// type, so checking that front is comparable makes == safe.
+	comparable = isComparable(front)
+	if comparable && front == back {
+		return back, false, false
+	}
+	if err, ok := limitDepth(back, front); ok {
+		return err, false, false
+	}
+	if OnWith != nil {
+		OnWith(back, front)
+	}
+	return nil, comparable, true
+}
+
+// OnWith, if not nil, is called each time With or any other function in this
+// package wraps a front error over back, such as Withf, WithSep, WithStack or
+// WithCode, for counting or sampling errors as they are wrapped. It isn't
+// called when they return without wrapping, because back or front is nil,
+// they are the same error or match with DedupMatching set, or MaxDepth was
+// reached.
+//
+// With may be called from many goroutines at once, so OnWith must be safe for
+// concurrent use, and it should be fast, since it runs on every call. Like
+// DefaultSeparator, it isn't safe to change concurrently with creating errors,
+// so it should only be set during program initialization.
+var OnWith func(back, front error)
+
+// DedupMatching, if true, makes With return back unchanged whenever
+// errors.Is(front, back) is true, not just when they are the same error. This
+// catches a front error that wraps back or claims to be it with an Is method,
+// but it costs a call to errors.Is on every call to With, so it defaults to
+// false. Like DefaultSeparator, it should only be set during program
+// initialization.
+var DedupMatching bool
+
+// WithAll returns an error that represents each of fronts wrapped in turn over
+// back, so that the last error in fronts ends up outermost. It is equivalent to
+// nesting calls to With, so With(With(back, a), b) is the same as WithAll(back,
+// a, b). Nil errors in fronts are skipped, and if back is nil, the returned
+// error is nil.
+func WithAll(back error, fronts ...error) error {
+	err := back
+	for _, front := range fronts {
+		err = With(err, front)
+	}
+	return err
+}
+
+// Withf returns an error that represents an error built from format and args
+// wrapped over back. The front error is created with fmt.Errorf, so it may
+// itself wrap errors using %w. Unlike With, if back is nil, the formatted error
+// is returned on its own.
+func Withf(back error, format string, args ...interface{}) error {
+	front := fmt.Errorf(format, args...)
+	if back == nil {
+		return front
+	}
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	s := makeStack(front, back, comparable)
+	return &s
+}
+
+// Chainf is the same as Withf, for adding context to an error that has already
+// been annotated. Since the formatted message is wrapped over err rather than
+// replacing it, everything the extractors in this package find in err, like
+// its status from WithStatus, severity from WithSeverity and tags from
+// WithTag, is still found in the returned error.
+func Chainf(err error, format string, args ...interface{}) error {
+	return Withf(err, format, args...)
+}
+
+// WithMessage returns an error that represents errors.New(msg) wrapped over
+// back. If msg is empty, back is returned unchanged, and if back is nil, the
+// returned error is just errors.New(msg).
+func WithMessage(back error, msg string) error {
+	if msg == "" {
+		return back
+	}
+	front := errors.New(msg)
+	if back == nil {
+		return front
+	}
+	err, comparable, ok := checkWrap(back, front)
+	if !ok {
+		return err
+	}
+	s := makeStack(front, back, comparable)
+	return &s
+}
+
+// WithUnless is like With, except that if back matches any of ignore, as in
+// IsAny, back is returned unchanged. This keeps expected errors like io.EOF
+// free of context meant for real failures.
+func WithUnless(back, front error, ignore ...error) error {
+	if IsAny(back, ignore...) {
 		return back
 	}
+	return With(back, front)
+}
 
-	return stack{front: front, back: back}
+// WithOnce is like With, except that if errors.Is(back, front) is already
+// true, back is returned unchanged. This keeps a sentinel applied by several
+// layers of middleware from appearing in the chain more than once. Unlike
+// Dedup, which removes duplicates from a chain that has already been built,
+// WithOnce never adds them.
+func WithOnce(back, front error) error {
+	if front != nil && errors.Is(back, front) {
+		return back
+	}
+	return With(back, front)
 }
 
 // stack represents a wrapped stack of errors.
+//
+// It is always used as a pointer, like the error returned by errors.New, so
+// that comparing two stacks with == is an identity check that can't panic, no
+// matter what errors they contain. Is also gives a stack target special
+// treatment, see contains.
 type stack struct {
 	front error
 	back  error
+
+	// comparable caches whether front's dynamic type is comparable, so that
+	// Is doesn't need to use reflection on every call.
+	comparable bool
+
+	// sep is written between the messages of front and back.
+	sep string
+
+	// reversed writes back's message before front's, see WithReversed.
+	reversed bool
+
+	// group is the name given to s by WithGroup, if any.
+	group string
+
+	// trace holds the program counters recorded by WithStack, if any.
+	trace []uintptr
+
+	// release returns a stack from WithPooled to the pool. Copies of a stack
+	// must clear it, so that they can't release the original.
+	release func()
+}
+
+// newStack returns a stack of front wrapped over back, using DefaultSeparator.
+func newStack(front, back error) *stack {
+	s := makeStack(front, back, isComparable(front))
+	return &s
+}
+
+// makeStack returns a stack of front wrapped over back, using
+// DefaultSeparator, where comparable says whether front is comparable, as
+// reported by isComparable. It is separate from newStack so that pooled
+// stacks can be reused without allocating, and so that callers that have
+// already checked front don't check it again.
+func makeStack(front, back error, comparable bool) stack {
+	return stack{
+		front:      front,
+		back:       back,
+		comparable: comparable,
+		sep:        DefaultSeparator,
+	}
+}
+
+// isComparable reports whether err's dynamic type is comparable, so that
+// comparing it with == can't panic.
+func isComparable(err error) bool {
+	return reflectlite.TypeOf(err).Comparable()
+}
+
+// sameType reports whether a and b have the same dynamic type.
+func sameType(a, b error) bool {
+	return reflectlite.TypeOf(a) == reflectlite.TypeOf(b)
 }
 
 // Is implements the interface needed for errors.Is. It checks s.front first, and
 // then s.back.
-func (s stack) Is(target error) bool {
-	// This code copied exactly from errors.Is, minus the code to unwrap if the
+//
+// Is itself doesn't use reflection or allocate, so errors.Is doesn't allocate
+// for chains built from errors that don't wrap other errors, like sentinels.
+// When a front error wraps other errors, Unwrap makes a new stack for each of
+// them, so errors.Is allocates once for every error it unwraps from a front.
+func (s *stack) Is(target error) bool {
+	// This code copied from errors.Is, minus the code to unwrap if the
 	// check fails. Thus, it is effectively like calling errors.Is(s.front,
 	// target).
 	//
@@ -46,9 +248,20 @@ func (s stack) Is(target error) bool {
 	if target == nil {
 		return false
 	}
+	if t, ok := target.(*stack); ok && s.contains(t) {
+		return true
+	}
+	if isMulti(s.front) {
+		// Unwrap can only return a single error, so it can't step into the
+		// branches of a multi-error in front. Search the whole tree here
+		// instead.
+		return errors.Is(s.front, target)
+	}
 
-	isComparable := reflectlite.TypeOf(target).Comparable()
-	if isComparable && s.front == target {
+	// errors.Is checks that target is comparable before using ==, but
+	// comparing interfaces can only panic if both hold the same type, so
+	// checking front instead is just as safe, and was done when s was made.
+	if s.comparable && s.front == target {
 		return true
 	}
 	if x, ok := s.front.(interface{ Is(error) bool }); ok && x.Is(target) {
@@ -59,28 +272,28 @@ func (s stack) Is(target error) bool {
 }
 
 // As implements the interface needed for errors.As. It checks s.front first, and
-// then s.back.
-func (s stack) As(target interface{}) bool {
-	// This code copied exactly from errors.As, minus the code to unwrap if the
-	// check fails. Thus, it is effectively like calling errors.As(s.front,
-	// target).
+// then s.back. Every error in front's chain, including any branches of a
+// multi-error, is checked before anything in back, so when more than one error
+// matches, errors.As finds the outermost one, in the same order as Chain.
+func (s *stack) As(target interface{}) bool {
+	// This code copied from errors.As, with the panic messages changed to name
+	// this package and minus the code to unwrap if the check fails. Thus, it
+	// is effectively like calling errors.As(s.front, target). The checks that
+	// use reflection are cached by type, see asTargetType and assignableTo.
 	//
 	// Note, if s.front doesn't match the target, errors.As will call this types
 	// Unwrap, which will iterate through the wrapped errors.
 
 	if target == nil {
-		panic("errors: target cannot be nil")
+		panic("wrap: target cannot be nil")
 	}
 	val := reflectlite.ValueOf(target)
-	typ := val.Type()
-	if typ.Kind() != reflectlite.Ptr || val.IsNil() {
-		panic("errors: target must be a non-nil pointer")
+	targetType := asTargetType(val)
+	if isMulti(s.front) {
+		// See the comment in Is.
+		return errors.As(s.front, target)
 	}
-	targetType := typ.Elem()
-	if targetType.Kind() != reflectlite.Interface && !targetType.Implements(errorType) {
-		panic("errors: *target must be interface or implement error")
-	}
-	if reflectlite.TypeOf(s.front).AssignableTo(targetType) {
+	if assignableTo(reflectlite.TypeOf(s.front), targetType) {
 		val.Elem().Set(reflectlite.ValueOf(s.front))
 		return true
 	}
@@ -90,31 +303,170 @@ func (s stack) As(target interface{}) bool {
 	return false
 }
 
+// asTargets maps pointer types that have been checked as targets for As to
+// their element types.
+var asTargets sync.Map
+
+// asTargetType returns the type that val, the target passed to As, points to.
+// It panics if val isn't a valid target, as errors.As does.
+func asTargetType(val reflectlite.Value) reflectlite.Type {
+	typ := val.Type()
+	if t, ok := asTargets.Load(typ); ok {
+		if val.IsNil() {
+			panic("wrap: target must be a non-nil pointer")
+		}
+		return t.(reflectlite.Type)
+	}
+	if typ.Kind() != reflectlite.Ptr || val.IsNil() {
+		panic("wrap: target must be a non-nil pointer")
+	}
+	targetType := typ.Elem()
+	if targetType.Kind() != reflectlite.Interface && !targetType.Implements(errorType) {
+		panic("wrap: *target must be interface or implement error")
+	}
+	asTargets.Store(typ, targetType)
+	return targetType
+}
+
+// typePair is the key for assignable.
+type typePair struct {
+	from, to reflectlite.Type
+}
+
+// assignable caches the results of assignableTo for interface types, since
+// checking whether a type implements an interface means comparing their
+// method sets. Like asTargets, it only grows as large as the number of types
+// used with As.
+var assignable sync.Map
+
+// assignableTo reports whether a value of type from is assignable to type to.
+func assignableTo(from, to reflectlite.Type) bool {
+	if to.Kind() != reflectlite.Interface {
+		return from.AssignableTo(to)
+	}
+	key := typePair{from: from, to: to}
+	if ok, found := assignable.Load(key); found {
+		return ok.(bool)
+	}
+	ok := from.AssignableTo(to)
+	assignable.Store(key, ok)
+	return ok
+}
+
+// contains reports whether both t's front and back errors can be found with
+// errors.Is in s's chain. That makes errors.Is(err, With(a, b)) true whenever
+// errors.Is(err, a) and errors.Is(err, b) are both true, wherever a and b are
+// in err's chain and whatever else is wrapped between or around them. It
+// treats nested stacks in t the same way, since errors.Is(s, t.front) is
+// handled by contains again if t.front is a stack.
+//
+// Errors that aren't comparable or don't have Is methods can't be found by
+// errors.Is, so a target containing one never matches, except by being the
+// same stack.
+func (s *stack) contains(t *stack) bool {
+	return errors.Is(s, t.front) && errors.Is(s, t.back)
+}
+
 var errorType = reflectlite.TypeOf((*error)(nil)).Elem()
 
+// isMulti reports whether err wraps multiple errors, as returned by
+// errors.Join or fmt.Errorf with more than one %w verb.
+func isMulti(err error) bool {
+	_, ok := err.(interface{ Unwrap() []error })
+	return ok
+}
+
 // Unwrap iteratively unwraps the error stack in front until it runs, out of
 // wrapped errors, and then returns the back error.
-func (s stack) Unwrap() error {
+//
+// A type can't have both Unwrap() error and Unwrap() []error, so stack keeps
+// the linear form. If front wraps multiple errors, Is and As search all of its
+// branches before Unwrap moves on to back, so the tree is still visited front
+// first. A multi-error in back is handled by the errors package as usual once
+// Unwrap returns it.
+func (s *stack) Unwrap() error {
 	if err := errors.Unwrap(s.front); err != nil {
 		// return a new stack with the unwrapped err as front, so that we
 		// support unwrapping all of front and then moving on to back.
-		return stack{front: err, back: s.back}
+		next := newStack(err, s.back)
+		next.sep = s.sep
+		next.reversed = s.reversed
+		return next
 	}
 	// Otherwise we ran out of errors in front to unwrap, so return the
 	// underlying error.
 	return s.back
 }
 
-// Error returns the two concatenated error strings, separated by a colon if
-// they are both non-empty.
-func (s stack) Error() string {
-	front := s.front.Error()
-	back := s.back.Error()
+// Cause returns s.back, following the convention from github.com/pkg/errors so
+// that code calling errors.Cause from that package can see past s. Like
+// Bottom, it only goes down one level, but pkg/errors' Cause will keep calling
+// it until it reaches an error without a Cause method.
+func (s *stack) Cause() error {
+	return s.back
+}
+
+// Error returns the two concatenated error strings, separated by s.sep if they
+// are both non-empty, with back's first if s was made by WithReversed. If
+// front's message is empty, it returns back's message, and if back's message
+// is empty, it returns front's.
+func (s *stack) Error() string {
+	// Concatenating the strings at each level would copy the message of back
+	// once for every stack above it, so nested stacks are written into a
+	// single builder instead. The result is the same, since joining the
+	// non-empty messages of front and back at each level is the same as
+	// joining the non-empty messages of all the errors in the stack, as long
+	// as each pair is separated by the separator of the stack that joined
+	// them.
+	_, nestedFront := s.front.(*stack)
+	_, nestedBack := s.back.(*stack)
+	if !nestedFront && !nestedBack {
+		if s.reversed {
+			return joinMessages(s.back.Error(), s.front.Error(), s.sep)
+		}
+		return joinMessages(s.front.Error(), s.back.Error(), s.sep)
+	}
+	var b strings.Builder
+	writeMessage(&b, s, "")
+	return b.String()
+}
+
+// joinMessages joins front and back with sep if they are both non-empty.
+func joinMessages(front, back, sep string) string {
 	if front == "" {
 		return back
 	}
 	if back == "" {
 		return front
 	}
-	return front + ": " + back
+	return front + sep + back
+}
+
+// writeMessage writes err's message to b, as described in stack.Error. If
+// anything has already been written to b, lead is written before the message,
+// unless the message is empty.
+func writeMessage(b *strings.Builder, err error, lead string) {
+	if s, ok := err.(*stack); ok {
+		first, second := s.front, s.back
+		if s.reversed {
+			first, second = second, first
+		}
+		n := b.Len()
+		writeMessage(b, first, lead)
+		if b.Len() > n {
+			// first wrote something, so the second message needs
+			// separating from it by this stack's separator.
+			lead = s.sep
+		}
+		writeMessage(b, second, lead)
+		return
+	}
+	msg := err.Error()
+	if msg == "" {
+		return
+	}
+	if b.Len() > 0 {
+		b.WriteString(lead)
+	}
+	b.WriteString(msg)
 }
@@ -0,0 +1,149 @@
+package wrap_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+var (
+	benchBool   bool
+	benchErr    error
+	benchString string
+)
+
+var benchDepths = []int{1, 8, 64}
+
+// benchChain returns myError("some pig") with depth flags wrapped over it.
+func benchChain(depth int) error {
+	var err error = myError("some pig")
+	for i := 0; i < depth; i++ {
+		err = wrap.With(err, fmt.Errorf("flag %d", i))
+	}
+	return err
+}
+
+func BenchmarkWith(b *testing.B) {
+	for _, depth := range benchDepths {
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			var base error = myError("some pig")
+			front := errors.New("flag")
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				err := base
+				for j := 0; j < depth; j++ {
+					err = wrap.With(err, front)
+				}
+				benchErr = err
+			}
+		})
+	}
+}
+
+func BenchmarkIs(b *testing.B) {
+	for _, depth := range benchDepths {
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			err := benchChain(depth)
+			var target error = myError("some pig")
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchBool = errors.Is(err, target)
+			}
+		})
+	}
+	b.Run("wrapping-front", func(b *testing.B) {
+		var err error = myError("some pig")
+		for i := 0; i < 8; i++ {
+			err = wrap.With(err, fmt.Errorf("flag %d: %w", i, io.EOF))
+		}
+		var target error = myError("some pig")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchBool = errors.Is(err, target)
+		}
+	})
+	b.Run("non-comparable", func(b *testing.B) {
+		err := benchChain(8)
+		var target error = sliceError{"some", "pig"}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchBool = errors.Is(err, target)
+		}
+	})
+}
+
+func BenchmarkAs(b *testing.B) {
+	for _, depth := range benchDepths {
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			err := benchChain(depth)
+			var my myError
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchBool = errors.As(err, &my)
+			}
+		})
+	}
+}
+
+func BenchmarkAsInterface(b *testing.B) {
+	for _, depth := range benchDepths {
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			err := benchChain(depth)
+			var target interface {
+				error
+				Unwrap() []error
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchBool = errors.As(err, &target)
+			}
+		})
+	}
+}
+
+func BenchmarkUnwrap(b *testing.B) {
+	for _, depth := range benchDepths {
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			err := benchChain(depth)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchErr = wrap.Root(err)
+			}
+		})
+	}
+}
+
+func BenchmarkError(b *testing.B) {
+	// depth-50 is kept to compare against earlier results.
+	for _, depth := range []int{1, 8, 50, 64} {
+		b.Run(fmt.Sprintf("depth-%d", depth), func(b *testing.B) {
+			err := benchChain(depth)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchString = err.Error()
+			}
+		})
+	}
+}
+
+func BenchmarkWithPooled(b *testing.B) {
+	var base error = myError("some pig")
+	front := errors.New("flag")
+	b.Run("With", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchBool = errors.Is(wrap.With(base, front), front)
+		}
+	})
+	b.Run("WithPooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			err, release := wrap.WithPooled(base, front)
+			benchBool = errors.Is(err, front)
+			release()
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package wrap
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var benchType reflect.Type
+
+var benchOK bool
+
+// The benchmarks below compare the checks As makes through asTargetType and
+// assignableTo with the same checks made with reflection on every call, as
+// errors.As does.
+
+func BenchmarkAsTargetType(b *testing.B) {
+	targets := map[string]reflect.Value{
+		"interface": reflect.ValueOf(new(interface {
+			error
+			Unwrap() error
+		})),
+		"concrete": reflect.ValueOf(new(*stack)),
+	}
+	for name, target := range targets {
+		b.Run(name+"-cached", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchType = asTargetType(target)
+			}
+		})
+		b.Run(name+"-uncached", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				typ := target.Type()
+				if typ.Kind() != reflect.Ptr || target.IsNil() {
+					b.Fatal("invalid target")
+				}
+				benchType = typ.Elem()
+				if benchType.Kind() != reflect.Interface && !benchType.Implements(errorType) {
+					b.Fatal("invalid target")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAssignableTo(b *testing.B) {
+	from := reflect.TypeOf(newStack(errors.New("flag"), errors.New("some pig")))
+	to := reflect.TypeOf((*interface {
+		error
+		Unwrap() error
+	})(nil)).Elem()
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchOK = assignableTo(from, to)
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchOK = from.AssignableTo(to)
+		}
+	})
+}
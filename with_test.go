@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"testing"
 
 	"github.com/natefinch/wrap"
@@ -106,3 +107,556 @@ func TestErrList(t *testing.T) {
 		t.Fatalf("expected %v but got %v", expected, actual)
 	}
 }
+
+func TestWithAll(t *testing.T) {
+	base := errors.New("base")
+	one := errors.New("one")
+	two := errors.New("two")
+	three := errors.New("three")
+
+	err := wrap.WithAll(base, one, nil, two, three)
+	for _, target := range []error{base, one, two, three} {
+		if !errors.Is(err, target) {
+			t.Fatalf("failed to find %q", target)
+		}
+	}
+	actual := err.Error()
+	expected := "three: two: one: base"
+	if actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	var my myError
+	err = wrap.WithAll(myError("some pig"), NotFound, io.EOF)
+	if !errors.As(err, &my) {
+		t.Fatal("failed to find original type")
+	}
+}
+
+func TestWithAllNil(t *testing.T) {
+	if err := wrap.WithAll(nil, nil, nil); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	if err := wrap.WithAll(nil, NotFound); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	base := errors.New("base")
+	if err := wrap.WithAll(base, nil); err != base {
+		t.Fatalf("expected base error but got %v", err)
+	}
+}
+
+func TestWithf(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.Withf(base, "user %d", 5)
+	actual := err.Error()
+	expected := "user 5: some pig"
+	if actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+	if errors.Unwrap(err) != base {
+		t.Fatal("failed to unwrap to original error")
+	}
+
+	err = wrap.Withf(nil, "user %d", 5)
+	if err == nil || err.Error() != "user 5" {
+		t.Fatalf("expected formatted error but got %v", err)
+	}
+}
+
+func TestChainf(t *testing.T) {
+	err := wrap.WithSeverity(wrap.WithStatus(errors.New("some pig"), http.StatusNotFound), wrap.SeverityWarn)
+	err = wrap.WithTag(wrap.WithCode(err, 7), "barn")
+	err = wrap.Chainf(err, "user %d", 5)
+
+	if actual, expected := err.Error(), "user 5: code 7: [WARN]: HTTP 404: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if status, ok := wrap.HTTPStatus(err); !ok || status != http.StatusNotFound {
+		t.Fatalf("expected status 404 but got %v", status)
+	}
+	if sev, ok := wrap.SeverityOf(err); !ok || sev != wrap.SeverityWarn {
+		t.Fatalf("expected WARN but got %v", sev)
+	}
+	if code, ok := wrap.Code(err); !ok || code != 7 {
+		t.Fatalf("expected code 7 but got %v", code)
+	}
+	if tags := wrap.Tags(err); len(tags) != 1 || tags[0] != "barn" {
+		t.Fatalf("expected tag barn but got %v", tags)
+	}
+}
+
+func TestWithMultiFront(t *testing.T) {
+	base := errors.New("base")
+	one := errors.New("one")
+	two := myError("two")
+
+	for _, front := range []error{
+		errors.Join(one, two),
+		fmt.Errorf("%w and %w", one, two),
+	} {
+		err := wrap.With(base, front)
+		if !errors.Is(err, one) {
+			t.Fatal("failed to find first joined error")
+		}
+		if !errors.Is(err, two) {
+			t.Fatal("failed to find second joined error")
+		}
+		if !errors.Is(err, base) {
+			t.Fatal("failed to find back error")
+		}
+		var my myError
+		if !errors.As(err, &my) || my != two {
+			t.Fatal("failed to find joined type")
+		}
+		if errors.Unwrap(err) != base {
+			t.Fatal("failed to unwrap to back error")
+		}
+	}
+}
+
+func TestWithMultiBack(t *testing.T) {
+	one := errors.New("one")
+	two := myError("two")
+
+	for _, back := range []error{
+		errors.Join(one, two),
+		fmt.Errorf("%w and %w", one, two),
+	} {
+		for _, err := range []error{
+			wrap.With(back, NotFound),
+			wrap.With(wrap.With(back, fmt.Errorf("wilbur: %w", io.EOF)), NotFound),
+			wrap.With(wrap.With(back, NotFound), errors.Join(io.EOF, io.ErrClosedPipe)),
+		} {
+			if !errors.Is(err, one) {
+				t.Errorf("%v: failed to find first joined error", err)
+			}
+			if !errors.Is(err, two) {
+				t.Errorf("%v: failed to find second joined error", err)
+			}
+			if !errors.Is(err, NotFound) {
+				t.Errorf("%v: failed to find front error", err)
+			}
+			var my myError
+			if !errors.As(err, &my) || my != two {
+				t.Errorf("%v: failed to find joined type", err)
+			}
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("%v: unexpectedly matched", err)
+			}
+		}
+	}
+}
+
+type sliceError []string
+
+func (s sliceError) Error() string {
+	return fmt.Sprint([]string(s))
+}
+
+func TestWithComparable(t *testing.T) {
+	base := sliceError{"some", "pig"}
+	stored := wrap.With(base, NotFound)
+	if stored == wrap.With(base, NotFound) {
+		t.Fatal("expected distinct stacks to compare unequal")
+	}
+	same := stored
+	if same != stored || !errors.Is(same, stored) {
+		t.Fatal("expected stack to equal itself")
+	}
+
+	chain := wrap.With(stored, io.EOF)
+	if !errors.Is(chain, stored) {
+		t.Fatal("failed to find stored stack")
+	}
+	if errors.Is(chain, wrap.With(base, NotFound)) {
+		t.Fatal("unexpectedly matched stack with non-comparable back error")
+	}
+	if !errors.Is(wrap.With(wrap.With(io.EOF, NotFound), io.ErrClosedPipe), wrap.With(io.EOF, NotFound)) {
+		t.Fatal("failed to find equivalent stack")
+	}
+	if errors.Is(chain, wrap.With(sliceError{"other"}, NotFound)) {
+		t.Fatal("unexpectedly found stack with different back error")
+	}
+	if errors.Is(chain, wrap.With(base, io.ErrUnexpectedEOF)) {
+		t.Fatal("unexpectedly found stack with different front error")
+	}
+	if errors.Is(stored, sliceError{"some", "pig"}) {
+		t.Fatal("unexpectedly matched non-comparable target")
+	}
+	if errors.Is(wrap.With(NotFound, base), sliceError{"some", "pig"}) {
+		t.Fatal("unexpectedly matched non-comparable target in front")
+	}
+}
+
+func TestErrorNested(t *testing.T) {
+	empty := errors.New("")
+	one := errors.New("one")
+	two := errors.New("two")
+
+	err := wrap.With(wrap.With(wrap.With(two, empty), empty), wrap.With(empty, one))
+	actual := err.Error()
+	expected := "one: two"
+	if actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+}
+
+func TestWithMessage(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithMessage(base, "wilbur")
+	if actual, expected := err.Error(), "wilbur: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+
+	if err := wrap.WithMessage(base, ""); err != base {
+		t.Fatalf("expected original error but got %v", err)
+	}
+	err = wrap.WithMessage(nil, "wilbur")
+	if err == nil || err.Error() != "wilbur" {
+		t.Fatalf("expected message error but got %v", err)
+	}
+}
+
+func TestAsPanics(t *testing.T) {
+	var my myError
+	err := wrap.With(errors.New("some pig"), NotFound)
+	as := err.(interface{ As(interface{}) bool })
+
+	tests := []struct {
+		target   interface{}
+		expected string
+	}{
+		{nil, "wrap: target cannot be nil"},
+		{my, "wrap: target must be a non-nil pointer"},
+		{(*myError)(nil), "wrap: target must be a non-nil pointer"},
+		{(*error)(nil), "wrap: target must be a non-nil pointer"},
+		{new(int), "wrap: *target must be interface or implement error"},
+	}
+	// Run each case twice, since checked target types are cached.
+	for _, test := range append(tests, tests...) {
+		func() {
+			defer func() {
+				if actual := recover(); actual != test.expected {
+					t.Errorf("expected panic %q but got %v", test.expected, actual)
+				}
+			}()
+			as.As(test.target)
+		}()
+	}
+}
+
+func TestErrorEmpty(t *testing.T) {
+	empty := errors.New("")
+	base := errors.New("some pig")
+
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{wrap.With(base, empty), "some pig"},
+		{wrap.With(empty, NotFound), "not found"},
+		{wrap.With(empty, empty), ""},
+		{wrap.WithSep(base, empty, " -> "), "some pig"},
+		{wrap.WithSep(empty, NotFound, " -> "), "not found"},
+	}
+	for _, test := range tests {
+		if actual := test.err.Error(); actual != test.expected {
+			t.Errorf("expected %q but got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestIsStackTarget(t *testing.T) {
+	base := errors.New("some pig")
+	chain := wrap.WithAll(base, NotFound, io.EOF, io.ErrClosedPipe)
+
+	tests := []struct {
+		target   error
+		expected bool
+	}{
+		{wrap.With(base, NotFound), true},
+		{wrap.With(NotFound, io.ErrClosedPipe), true},
+		{wrap.With(io.ErrClosedPipe, base), true},
+		{wrap.WithAll(base, NotFound, io.ErrClosedPipe), true},
+		{wrap.With(wrap.With(base, io.EOF), wrap.With(NotFound, io.ErrClosedPipe)), true},
+		{wrap.With(base, io.ErrUnexpectedEOF), false},
+		{wrap.With(io.ErrUnexpectedEOF, NotFound), false},
+		{wrap.WithAll(base, NotFound, io.ErrUnexpectedEOF), false},
+	}
+	for _, test := range tests {
+		if actual := errors.Is(chain, test.target); actual != test.expected {
+			t.Errorf("%v: expected %v but got %v", test.target, test.expected, actual)
+		}
+	}
+
+	if !errors.Is(fmt.Errorf("context: %w", chain), wrap.With(base, NotFound)) {
+		t.Fatal("failed to find sub-stack after wrapping")
+	}
+	if errors.Is(base, wrap.With(base, NotFound)) {
+		t.Fatal("unexpectedly matched stack target against a plain error")
+	}
+}
+
+func TestCause(t *testing.T) {
+	base := errors.New("some pig")
+	wrapped := fmt.Errorf("wilbur: %w", base)
+	err := wrap.With(wrapped, NotFound)
+
+	causer, ok := err.(interface{ Cause() error })
+	if !ok {
+		t.Fatal("expected error to have a Cause method")
+	}
+	if cause := causer.Cause(); cause != wrapped {
+		t.Fatalf("expected %v but got %v", wrapped, cause)
+	}
+}
+
+func TestWithUnless(t *testing.T) {
+	if err := wrap.WithUnless(io.EOF, ErrTxnFailed, io.EOF, io.ErrUnexpectedEOF); err != io.EOF {
+		t.Fatalf("expected io.EOF unchanged but got %v", err)
+	}
+	base := errors.New("some pig")
+	err := wrap.WithUnless(base, ErrTxnFailed, io.EOF)
+	if !errors.Is(err, ErrTxnFailed) || !errors.Is(err, base) {
+		t.Fatalf("expected wrapped error but got %v", err)
+	}
+	if err := wrap.WithUnless(nil, ErrTxnFailed, io.EOF); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestWithOnce(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WithOnce(base, NotFound)
+	err = wrap.WithOnce(fmt.Errorf("context: %w", err), NotFound)
+	if n := wrap.Count(err, NotFound); n != 1 {
+		t.Fatalf("expected sentinel once but found it %d times", n)
+	}
+	if actual, expected := err.Error(), "context: not found: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if again := wrap.WithOnce(err, io.EOF); !errors.Is(again, io.EOF) {
+		t.Fatal("expected missing sentinel to be added")
+	}
+	if err := wrap.WithOnce(nil, NotFound); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestAsMethod(t *testing.T) {
+	base := myError("some pig")
+	err := wrap.With(base, NotFound)
+	as := err.(interface{ As(interface{}) bool })
+
+	var e error
+	if !as.As(&e) || e != NotFound {
+		t.Fatalf("expected front error but got %v", e)
+	}
+	var my myError
+	if as.As(&my) {
+		t.Fatal("unexpectedly matched back type")
+	}
+	var wrapper interface {
+		error
+		Unwrap() error
+	}
+	for i := 0; i < 2; i++ {
+		if as.As(&wrapper) {
+			t.Fatal("unexpectedly matched interface")
+		}
+	}
+	err = wrap.With(base, fmt.Errorf("wilbur: %w", NotFound))
+	as = err.(interface{ As(interface{}) bool })
+	for i := 0; i < 2; i++ {
+		if !as.As(&wrapper) || wrapper.Error() != "wilbur: not found" {
+			t.Fatalf("expected front error to match interface but got %v", wrapper)
+		}
+	}
+}
+
+// TestIsAllocs checks that errors.Is doesn't allocate when no front error
+// wraps another error, and allocates once per error unwrapped from a front
+// otherwise, since Unwrap has to return a new stack for each.
+func TestIsAllocs(t *testing.T) {
+	plain := wrap.With(wrap.With(errors.New("some pig"), NotFound), io.EOF)
+	wrapping := wrap.With(wrap.With(errors.New("some pig"), NotFound), fmt.Errorf("wilbur: %w", io.EOF))
+	var nonComparable error = sliceError{"some", "pig"}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		allocs float64
+	}{
+		{"front", plain, io.EOF, 0},
+		{"nested", plain, NotFound, 0},
+		{"missing", plain, io.ErrUnexpectedEOF, 0},
+		{"non-comparable", plain, nonComparable, 0},
+		{"wrapping front", wrapping, fmt.Errorf("other"), 1},
+		{"wrapped in front", wrapping, io.EOF, 1},
+		{"past wrapping front", wrapping, NotFound, 1},
+	}
+	for _, test := range tests {
+		allocs := testing.AllocsPerRun(100, func() {
+			errors.Is(test.err, test.target)
+		})
+		if allocs != test.allocs {
+			t.Errorf("%s: expected %v allocations but got %v", test.name, test.allocs, allocs)
+		}
+	}
+}
+
+func TestAsBackOnly(t *testing.T) {
+	fronts := []struct {
+		name  string
+		front error
+	}{
+		{"plain", errors.New("wilbur")},
+		{"wrapping", fmt.Errorf("wilbur: %w", io.EOF)},
+		{"multi", errors.Join(io.EOF, NotFound)},
+		{"stack", wrap.With(io.EOF, NotFound)},
+		{"non-comparable", sliceError{"wilbur"}},
+	}
+	backs := []struct {
+		name string
+		back error
+	}{
+		{"direct", myError("some pig")},
+		{"wrapped", fmt.Errorf("context: %w", myError("some pig"))},
+		{"stacked", wrap.With(myError("some pig"), errors.New("charlotte"))},
+	}
+	for _, f := range fronts {
+		for _, b := range backs {
+			err := wrap.With(b.back, f.front)
+			var my myError
+			if !errors.As(err, &my) || my != "some pig" {
+				t.Errorf("%s front, %s back: expected to find back error but got %q", f.name, b.name, my)
+			}
+			wrapped := wrap.With(wrap.With(err, errors.New("outer")), f.front)
+			my = ""
+			if !errors.As(wrapped, &my) || my != "some pig" {
+				t.Errorf("%s front, %s back, nested: expected to find back error but got %q", f.name, b.name, my)
+			}
+		}
+	}
+}
+
+func TestWithSame(t *testing.T) {
+	base := errors.New("some pig")
+	constructors := map[string]func(back, front error) error{
+		"With":          wrap.With,
+		"WithSep":       func(back, front error) error { return wrap.WithSep(back, front, " | ") },
+		"WithReversed":  wrap.WithReversed,
+		"WithStack":     wrap.WithStack,
+		"WithStackSkip": func(back, front error) error { return wrap.WithStackSkip(back, front, 1) },
+		"WithOpts":      func(back, front error) error { return wrap.WithOpts(back, front, wrap.RecordStack()) },
+		"WithPooled": func(back, front error) error {
+			err, _ := wrap.WithPooled(back, front)
+			return err
+		},
+	}
+	for name, with := range constructors {
+		if err := with(base, base); err != base {
+			t.Errorf("%s: expected back error unchanged but got %v", name, err)
+		}
+	}
+	nonComparable := sliceError{"some", "pig"}
+	if err := wrap.With(nonComparable, nonComparable); err.Error() != "[some pig]: [some pig]" {
+		t.Fatalf("expected uncomparable errors to be wrapped but got %v", err)
+	}
+
+	wrapping := fmt.Errorf("wilbur: %w", base)
+	if err := wrap.With(base, wrapping); err.Error() != "wilbur: some pig: some pig" {
+		t.Fatalf("expected matching errors to be wrapped by default but got %v", err)
+	}
+
+	defer func() { wrap.DedupMatching = false }()
+	wrap.DedupMatching = true
+	if err := wrap.With(base, wrapping); err != base {
+		t.Fatalf("expected back error unchanged but got %v", err)
+	}
+	if err := wrap.With(base, NotFound); err.Error() != "not found: some pig" {
+		t.Fatalf("expected unrelated errors to be wrapped but got %v", err)
+	}
+}
+
+func TestAsOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"front over back", wrap.With(myError("bottom"), myError("top"))},
+		{"nested front", wrap.With(myError("bottom"), wrap.With(myError("middle"), myError("top")))},
+		{"nested back", wrap.With(wrap.With(myError("bottom"), myError("middle")), myError("top"))},
+		{"wrapped front", wrap.With(myError("bottom"), fmt.Errorf("wilbur: %w", myError("top")))},
+		{"joined front", wrap.With(myError("bottom"), errors.Join(io.EOF, myError("top"), myError("middle")))},
+		{"wrapped stack", fmt.Errorf("wilbur: %w", wrap.With(myError("bottom"), myError("top")))},
+		{"rewrapped", wrap.With(wrap.With(myError("bottom"), NotFound), wrap.With(io.EOF, myError("top")))},
+	}
+	for _, test := range tests {
+		var my myError
+		if !errors.As(test.err, &my) || my != "top" {
+			t.Errorf("%s: expected outermost error but got %q", test.name, my)
+		}
+		if first, _ := wrap.First[myError](test.err); first != my {
+			t.Errorf("%s: expected First to agree with errors.As but got %q", test.name, first)
+		}
+	}
+}
+
+func TestOnWith(t *testing.T) {
+	var calls int
+	var last [2]error
+	defer func() { wrap.OnWith = nil }()
+	wrap.OnWith = func(back, front error) {
+		calls++
+		last = [2]error{back, front}
+	}
+
+	base := errors.New("some pig")
+	err := wrap.With(base, NotFound)
+	if calls != 1 || last != [2]error{base, NotFound} {
+		t.Fatalf("expected one call with back and front but got %d, %v", calls, last)
+	}
+	wrap.WithCode(err, 404)
+	if calls != 2 {
+		t.Fatalf("expected helpers built on With to call the hook but got %d calls", calls)
+	}
+
+	constructors := map[string]func() error{
+		"WithSep":      func() error { return wrap.WithSep(base, NotFound, " | ") },
+		"WithReversed": func() error { return wrap.WithReversed(base, NotFound) },
+		"WithStack":    func() error { return wrap.WithStack(base, NotFound) },
+		"WithOpts":     func() error { return wrap.WithOpts(base, NotFound) },
+		"Withf":        func() error { return wrap.Withf(base, "user %d", 5) },
+		"WithMessage":  func() error { return wrap.WithMessage(base, "wilbur") },
+		"WithPooled": func() error {
+			err, _ := wrap.WithPooled(base, NotFound)
+			return err
+		},
+	}
+	for name, with := range constructors {
+		before := calls
+		front, _ := wrap.Top(with())
+		if calls != before+1 || last[0] != base || last[1] != front {
+			t.Errorf("%s: expected one call with back and front but got %d, %v", name, calls-before, last)
+		}
+	}
+
+	calls = 0
+	wrap.With(nil, NotFound)
+	wrap.With(base, nil)
+	wrap.With(base, base)
+	wrap.WithSep(base, base, " | ")
+	wrap.Withf(nil, "user %d", 5)
+	if calls != 0 {
+		t.Fatalf("expected early returns not to call the hook but got %d calls", calls)
+	}
+}
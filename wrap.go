@@ -0,0 +1,40 @@
+package wrap
+
+// Wrap replaces the error errp points to with that error wrapped by front, as
+// in With. If *errp is nil, it is left nil. Wrap is meant to be deferred in
+// functions with a named error result:
+//
+//	func (db *DB) Commit() (err error) {
+//		defer wrap.Wrap(&err, ErrTxnFailed)
+//		...
+//	}
+func Wrap(errp *error, front error) {
+	if *errp != nil {
+		*errp = With(*errp, front)
+	}
+}
+
+// Wrapf is like Wrap, but wraps *errp with an error built from format and
+// args, as in Withf. If *errp is nil, it is left nil.
+func Wrapf(errp *error, format string, args ...interface{}) {
+	if *errp != nil {
+		*errp = Withf(*errp, format, args...)
+	}
+}
+
+// WrapFunc returns a function that calls fn and wraps any error it returns with
+// front, as in With. The value fn returns is passed through unchanged, and if
+// fn returns a nil error, so does the returned function.
+func WrapFunc[T any](fn func() (T, error), front error) func() (T, error) {
+	return func() (T, error) {
+		v, err := fn()
+		return v, With(err, front)
+	}
+}
+
+// WrapErrFunc is like WrapFunc, for functions that only return an error.
+func WrapErrFunc(fn func() error, front error) func() error {
+	return func() error {
+		return With(fn(), front)
+	}
+}
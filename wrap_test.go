@@ -0,0 +1,83 @@
+package wrap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+var ErrTxnFailed = errors.New("transaction failed")
+
+func commit(fail error) (err error) {
+	defer wrap.Wrap(&err, ErrTxnFailed)
+	return fail
+}
+
+func commitf(fail error) (err error) {
+	defer wrap.Wrapf(&err, "commit %d", 5)
+	return fail
+}
+
+func TestWrap(t *testing.T) {
+	base := errors.New("some pig")
+	err := commit(base)
+	if !errors.Is(err, ErrTxnFailed) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	if actual, expected := err.Error(), "transaction failed: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if err := commit(nil); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	base := errors.New("some pig")
+	err := commitf(base)
+	if !errors.Is(err, base) {
+		t.Fatal("failed to find original error")
+	}
+	if actual, expected := err.Error(), "commit 5: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+	if err := commitf(nil); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
+
+func TestWrapFunc(t *testing.T) {
+	base := errors.New("some pig")
+	fail := wrap.WrapFunc(func() (int, error) { return 5, base }, ErrTxnFailed)
+	v, err := fail()
+	if v != 5 {
+		t.Fatalf("expected 5 but got %v", v)
+	}
+	if !errors.Is(err, ErrTxnFailed) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	if actual, expected := err.Error(), "transaction failed: some pig"; actual != expected {
+		t.Fatalf("expected %v but got %v", expected, actual)
+	}
+
+	succeed := wrap.WrapFunc(func() (string, error) { return "ok", nil }, ErrTxnFailed)
+	s, err := succeed()
+	if err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+	if s != "ok" {
+		t.Fatalf("expected ok but got %v", s)
+	}
+}
+
+func TestWrapErrFunc(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.WrapErrFunc(func() error { return base }, ErrTxnFailed)()
+	if !errors.Is(err, ErrTxnFailed) || !errors.Is(err, base) {
+		t.Fatal("failed to find wrapped errors")
+	}
+	if err := wrap.WrapErrFunc(func() error { return nil }, ErrTxnFailed)(); err != nil {
+		t.Fatalf("expected nil but got %v", err)
+	}
+}
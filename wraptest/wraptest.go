@@ -0,0 +1,51 @@
+// Package wraptest provides helpers for testing code that uses package wrap.
+// It is separate from wrap, as net/http/httptest is from net/http, so that
+// programs using wrap don't link in package testing.
+package wraptest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/natefinch/wrap"
+)
+
+// AssertChain is a test helper that checks that errors.Is(err, target) is true
+// for each of want. Each target that isn't found is reported with t.Errorf,
+// so one call reports every mismatch, and then the errors in err's chain, as
+// returned by wrap.Chain, are logged one per line with their types and
+// messages, to show what was there instead.
+func AssertChain(t testing.TB, err error, want ...error) {
+	t.Helper()
+	var failed bool
+	for _, target := range want {
+		if !errors.Is(err, target) {
+			t.Errorf("error chain doesn't contain %s", describe(target))
+			failed = true
+		}
+	}
+	if !failed {
+		return
+	}
+	if err == nil {
+		t.Logf("error is nil")
+		return
+	}
+	var b strings.Builder
+	b.WriteString("error chain:")
+	for _, err := range wrap.Chain(err) {
+		b.WriteString("\n\t")
+		b.WriteString(describe(err))
+	}
+	t.Logf("%s", b.String())
+}
+
+// describe returns err's type and quoted message, as wrap.Dump writes them.
+func describe(err error) string {
+	if err == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%T %q", err, err.Error())
+}
@@ -0,0 +1,65 @@
+package wraptest_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/natefinch/wrap"
+	"github.com/natefinch/wrap/wraptest"
+)
+
+// NotFound is a sentinel for the tests.
+var NotFound = errors.New("not found")
+
+// fakeTB records the failures and logs reported to it.
+type fakeTB struct {
+	testing.TB
+	errors []string
+	logs   []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Logf(format string, args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func TestAssertChain(t *testing.T) {
+	base := errors.New("some pig")
+	err := wrap.With(fmt.Errorf("wilbur: %w", base), NotFound)
+
+	var pass fakeTB
+	wraptest.AssertChain(&pass, err, NotFound, base)
+	if len(pass.errors) != 0 || len(pass.logs) != 0 {
+		t.Fatalf("expected no failures but got %q, %q", pass.errors, pass.logs)
+	}
+
+	var fail fakeTB
+	wraptest.AssertChain(&fail, err, io.EOF, NotFound, io.ErrClosedPipe)
+	expected := []string{
+		`error chain doesn't contain *errors.errorString "EOF"`,
+		`error chain doesn't contain *errors.errorString "io: read/write on closed pipe"`,
+	}
+	if fmt.Sprintf("%q", fail.errors) != fmt.Sprintf("%q", expected) {
+		t.Fatalf("expected %q but got %q", expected, fail.errors)
+	}
+	chain := `error chain:
+	*errors.errorString "not found"
+	*fmt.wrapError "wilbur: some pig"
+	*errors.errorString "some pig"`
+	if len(fail.logs) != 1 || fail.logs[0] != chain {
+		t.Fatalf("expected chain to be logged once but got %q", fail.logs)
+	}
+
+	var nilErr fakeTB
+	wraptest.AssertChain(&nilErr, nil, NotFound)
+	if len(nilErr.errors) != 1 || len(nilErr.logs) != 1 || nilErr.logs[0] != "error is nil" {
+		t.Fatalf("expected nil error to be reported but got %q, %q", nilErr.errors, nilErr.logs)
+	}
+}